@@ -0,0 +1,70 @@
+// Command zendesk-tf-export writes Terraform configuration and import blocks
+// for the objects of a Zendesk account, so that an existing account can be
+// brought under Terraform management.
+//
+// The account is configured with the same ZENDESK_* environment variables as
+// the provider.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nukosuke/terraform-provider-zendesk/zendesk"
+)
+
+func main() {
+	out := flag.String("out", ".", "directory the .tf files are written to")
+	types := flag.String("types", strings.Join(zendesk.ExportTypes(), ","), "comma separated resource types to export")
+	flag.Parse()
+
+	if err := run(context.Background(), *out, strings.Split(*types, ",")); err != nil {
+		fmt.Fprintln(os.Stderr, "zendesk-tf-export:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, out string, types []string) error {
+	zd, diags := zendesk.NewClient(ctx, zendesk.ConfigFromEnv(), "zendesk-tf-export")
+	for _, d := range diags {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", d.Summary, d.Detail)
+	}
+	if diags.HasError() {
+		return fmt.Errorf("could not configure the Zendesk client")
+	}
+
+	for i := range types {
+		types[i] = strings.TrimSpace(types[i])
+	}
+	files, err := zendesk.Export(ctx, zd, types)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(out, 0o755)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(out, name)
+		err = os.WriteFile(path, files[name], 0o644)
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+
+	return nil
+}
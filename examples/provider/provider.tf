@@ -2,7 +2,8 @@
 #   https://developer.zendesk.com/rest_api/docs/support/introduction#security-and-authentication
 #
 # NOTE:
-#   currently only token authentication is supported
+#   API token, OAuth access token and OAuth client credentials
+#   authentication are supported. Configure exactly one of them.
 
 terraform {
   required_providers {
@@ -26,3 +27,44 @@ provider "zendesk" {
   # export ZENDESK_EMAIL="john.doe@example.com"
   # export ZENDESK_TOKEN="xxxxxxxxxx"
 }
+
+provider "zendesk" {
+  alias   = "oauth"
+  account = "example"
+
+  # OAuth access token (ZENDESK_OAUTH_TOKEN)
+  oauth_token = "xxxxxxxxxx"
+
+  # or OAuth client credentials (ZENDESK_CLIENT_ID / ZENDESK_CLIENT_SECRET)
+  # client_id     = "terraform"
+  # client_secret = "xxxxxxxxxx"
+}
+
+provider "zendesk" {
+  alias   = "multi"
+  account = "example"
+  email   = "john.doe@example.com"
+  token   = "xxxxxxxxxx"
+
+  # additional instances, selected per resource with `account`
+  accounts {
+    name    = "sandbox"
+    account = "example1700000000"
+    email   = "john.doe@example.com"
+    token   = "xxxxxxxxxx"
+  }
+
+  accounts {
+    name        = "eu"
+    account     = "example-eu"
+    oauth_token = "xxxxxxxxxx"
+  }
+}
+
+# objects of additional instances are imported with an "<name>/<id>" ID:
+#   terraform import zendesk_group.sandbox-group sandbox/360000000000
+resource "zendesk_group" "sandbox-group" {
+  provider = zendesk.multi
+  account  = "sandbox"
+  name     = "Moderator"
+}
@@ -0,0 +1,9 @@
+# API reference:
+#   https://developer.zendesk.com/api-reference/ticketing/business-rules/automations/#update-many-automations
+
+resource "zendesk_automation_order" "order" {
+  ids = [
+    zendesk_automation.close-solved.id,
+    zendesk_automation.remind-pending.id,
+  ]
+}
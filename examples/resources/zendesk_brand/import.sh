@@ -0,0 +1,11 @@
+# by ID
+terraform import zendesk_brand.support 360000012345
+
+# by name, which has to match exactly one brand
+terraform import zendesk_brand.support "name:Support"
+
+# by subdomain, which has to match exactly one brand
+terraform import zendesk_brand.support "subdomain:support"
+
+# of a named account from the provider accounts block
+terraform import zendesk_brand.support "sandbox/name:Support"
@@ -4,5 +4,14 @@
 resource "zendesk_custom_roles" "example" {
   name        = "Example Custom Role"
   description = "An example custom role"
-}
 
+  configuration {
+    ticket_access         = "within-groups"
+    ticket_comment_access = "public"
+    macro_access          = "manage-personal"
+    view_access           = "manage-personal"
+    ticket_editing        = true
+    ticket_merge          = true
+    ticket_deletion       = false
+  }
+}
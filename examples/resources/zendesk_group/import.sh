@@ -0,0 +1,8 @@
+# by ID
+terraform import zendesk_group.tier_2 360000012345
+
+# by name, which has to match exactly one group
+terraform import zendesk_group.tier_2 "name:Tier 2"
+
+# of a named account from the provider accounts block
+terraform import zendesk_group.tier_2 "sandbox/name:Tier 2"
@@ -0,0 +1,8 @@
+# by ID
+terraform import zendesk_macro.close_and_redirect 360000012345
+
+# by title, which has to match exactly one macro
+terraform import zendesk_macro.close_and_redirect "title:Close and redirect"
+
+# of a named account from the provider accounts block
+terraform import zendesk_macro.close_and_redirect "sandbox/title:Close and redirect"
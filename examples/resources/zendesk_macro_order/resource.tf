@@ -0,0 +1,9 @@
+# API reference:
+#   https://developer.zendesk.com/api-reference/ticketing/business-rules/macros/#update-many-macros
+
+resource "zendesk_macro_order" "order" {
+  ids = [
+    zendesk_macro.close-and-redirect.id,
+    zendesk_macro.request-details.id,
+  ]
+}
@@ -0,0 +1,15 @@
+# API reference:
+#   https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_clients/
+
+resource "zendesk_oauth_client" "integration" {
+  name         = "Internal Integration"
+  identifier   = "internal_integration"
+  kind         = "confidential"
+  description  = "Client used by the internal ticket sync"
+  redirect_uri = ["https://integration.example.com/oauth/callback"]
+}
+
+output "integration_client_secret" {
+  value     = zendesk_oauth_client.integration.secret
+  sensitive = true
+}
@@ -0,0 +1,22 @@
+# API reference:
+#   https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_tokens/
+
+resource "zendesk_oauth_token" "ticket_sync" {
+  client_id = zendesk_oauth_client.integration.id
+  scopes    = ["tickets:read", "users:write"]
+
+  # change any value to issue a new token and revoke the old one
+  rotation_trigger = {
+    rotated_on = "2024-01-01"
+  }
+
+  # issue the new token before the old one is revoked
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+output "ticket_sync_token" {
+  value     = zendesk_oauth_token.ticket_sync.full_token
+  sensitive = true
+}
@@ -0,0 +1,11 @@
+# by ID
+terraform import zendesk_organization_field.region 360000012345
+
+# by key, which has to match exactly one organization field
+terraform import zendesk_organization_field.region "key:region"
+
+# by title, which has to match exactly one organization field
+terraform import zendesk_organization_field.region "title:Region"
+
+# of a named account from the provider accounts block
+terraform import zendesk_organization_field.region "sandbox/key:region"
@@ -1,8 +1,31 @@
 # API reference:
 #   https://developer.zendesk.com/api-reference/ticketing/queues/
 
+resource "zendesk_group" "tier_1" {
+  name = "Tier 1"
+}
+
+resource "zendesk_group" "tier_2" {
+  name = "Tier 2"
+}
+
 resource "zendesk_queues" "example" {
   name        = "Example Queue"
-  description = "An example queue"
-}
+  description = "Urgent tickets of VIP customers"
+  priority    = 1
+
+  all {
+    field    = "priority"
+    operator = "is"
+    value    = "urgent"
+  }
 
+  any {
+    field    = "current_tags"
+    operator = "includes"
+    value    = "vip"
+  }
+
+  primary_groups   = [zendesk_group.tier_1.id]
+  secondary_groups = [zendesk_group.tier_2.id]
+}
@@ -0,0 +1,9 @@
+# API reference:
+#   https://developer.zendesk.com/api-reference/ticketing/business-rules/sla_policies/#reorder-sla-policies
+
+resource "zendesk_sla_policy_order" "order" {
+  ids = [
+    zendesk_sla_policy.vip.id,
+    zendesk_sla_policy.default.id,
+  ]
+}
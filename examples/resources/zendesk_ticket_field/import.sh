@@ -0,0 +1,8 @@
+# by ID
+terraform import zendesk_ticket_field.order_number 360000012345
+
+# by title, which has to match exactly one ticket field
+terraform import zendesk_ticket_field.order_number "title:Order number"
+
+# of a named account from the provider accounts block
+terraform import zendesk_ticket_field.order_number "sandbox/title:Order number"
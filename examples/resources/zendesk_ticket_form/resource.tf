@@ -29,4 +29,49 @@ resource "zendesk_ticket_form" "form-2" {
     zendesk_ticket_field.text-field.id,
     zendesk_ticket_field.textarea-field.id,
   ]
+
+  agent_conditions {
+    parent_field_id = zendesk_ticket_field.tagger-field.id
+    value           = "opt1"
+
+    child_fields {
+      id          = zendesk_ticket_field.text-field.id
+      is_required = false
+
+      required_on_statuses {
+        type     = "SOME_STATUSES"
+        statuses = ["solved"]
+      }
+    }
+  }
+
+  end_user_conditions {
+    parent_field_id = zendesk_ticket_field.tagger-field.id
+    value           = "opt1"
+
+    child_fields {
+      id          = zendesk_ticket_field.text-field.id
+      is_required = true
+    }
+  }
+}
+
+resource "zendesk_ticket_form" "form-3" {
+  name = "Form 3"
+
+  display_name_variant {
+    locale  = "en-US"
+    content = "Report a problem"
+  }
+
+  display_name_variant {
+    locale  = "de"
+    content = "Problem melden"
+  }
+}
+
+# Copy the fields and conditions of Form 2, for example for another brand
+resource "zendesk_ticket_form" "form-2-copy" {
+  name           = "Form 2 (copy)"
+  source_form_id = zendesk_ticket_form.form-2.id
 }
@@ -0,0 +1,9 @@
+# API reference:
+#   https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#reorder-ticket-forms
+
+resource "zendesk_ticket_form_order" "order" {
+  ids = [
+    zendesk_ticket_form.default.id,
+    zendesk_ticket_form.billing.id,
+  ]
+}
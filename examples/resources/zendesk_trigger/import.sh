@@ -0,0 +1,8 @@
+# by ID
+terraform import zendesk_trigger.escalate_vip 360000012345
+
+# by title, which has to match exactly one trigger
+terraform import zendesk_trigger.escalate_vip "title:Escalate VIP"
+
+# of a named account from the provider accounts block
+terraform import zendesk_trigger.escalate_vip "sandbox/title:Escalate VIP"
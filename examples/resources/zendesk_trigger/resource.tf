@@ -25,10 +25,28 @@ resource "zendesk_trigger" "auto-reply-trigger" {
 
   action {
     field = "notification_user"
-    value = jsonencode([
-      "requester_id",
-      "Dear my customer",
-      "Hi. This message was configured by terraform-provider-zendesk."
-    ])
+    notification_user {
+      recipient = "requester_id"
+      subject   = "Dear my customer"
+      body      = "Hi. This message was configured by terraform-provider-zendesk."
+    }
+  }
+}
+
+resource "zendesk_trigger" "notify-slack-trigger" {
+  title = "Notify Slack about urgent tickets"
+
+  all {
+    field    = "priority"
+    operator = "is"
+    value    = "urgent"
+  }
+
+  webhook_notification {
+    webhook_id = zendesk_webhook.slack.id
+    payload = jsonencode({
+      text    = "Ticket {{ticket.id}} is urgent: {{ticket.title}}"
+      channel = "#support"
+    })
   }
 }
@@ -0,0 +1,10 @@
+# API reference:
+#   https://developer.zendesk.com/api-reference/ticketing/business-rules/triggers/#reorder-triggers
+
+resource "zendesk_trigger_order" "order" {
+  ids = [
+    zendesk_trigger.auto-reply.id,
+    zendesk_trigger.escalate-vip.id,
+    zendesk_trigger.notify-slack.id,
+  ]
+}
@@ -0,0 +1,11 @@
+# by ID
+terraform import zendesk_user_field.account_tier 360000012345
+
+# by key, which has to match exactly one user field
+terraform import zendesk_user_field.account_tier "key:account_tier"
+
+# by title, which has to match exactly one user field
+terraform import zendesk_user_field.account_tier "title:Account tier"
+
+# of a named account from the provider accounts block
+terraform import zendesk_user_field.account_tier "sandbox/key:account_tier"
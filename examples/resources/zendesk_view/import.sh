@@ -0,0 +1,8 @@
+# by ID
+terraform import zendesk_view.vip_tickets 360000012345
+
+# by title, which has to match exactly one view
+terraform import zendesk_view.vip_tickets "title:VIP tickets"
+
+# of a named account from the provider accounts block
+terraform import zendesk_view.vip_tickets "sandbox/title:VIP tickets"
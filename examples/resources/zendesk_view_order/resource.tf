@@ -0,0 +1,9 @@
+# API reference:
+#   https://developer.zendesk.com/api-reference/ticketing/business-rules/views/#update-many-views
+
+resource "zendesk_view_order" "order" {
+  ids = [
+    zendesk_view.unassigned.id,
+    zendesk_view.my-open-tickets.id,
+  ]
+}
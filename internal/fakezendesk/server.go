@@ -0,0 +1,590 @@
+// Package fakezendesk is an in-memory stand-in for the parts of the Zendesk
+// REST API which the provider uses, so that the acceptance tests can run
+// without a Zendesk account.
+//
+// Collections are generic: an object posted to /api/v2/<collection>.json
+// under the singular key of the collection is stored with a new ID, and can
+// be read, updated and deleted at /api/v2/<collection>/<id>.json. Objects are
+// kept as decoded JSON, so any field the provider sends is returned as is.
+package fakezendesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Object is a Zendesk object as decoded from JSON
+type Object = map[string]interface{}
+
+// envelopes holds the plural and singular keys of collections whose keys
+// aren't their last path segment with and without the trailing s
+var envelopes = map[string][2]string{
+	"slas/policies":         {"sla_policies", "sla_policy"},
+	"dynamic_content/items": {"items", "item"},
+	"custom_statuses":       {"custom_statuses", "custom_status"},
+}
+
+// softDeleted holds the fields set by deleting objects of collections which
+// Zendesk keeps after deletion
+var softDeleted = map[string]Object{
+	"groups":       {"deleted": true},
+	"ticket_forms": {"active": false},
+}
+
+// activeByDefault lists the collections whose objects are created active
+var activeByDefault = map[string]bool{
+	"automations":         true,
+	"brands":              true,
+	"macros":              true,
+	"organization_fields": true,
+	"slas/policies":       true,
+	"ticket_fields":       true,
+	"ticket_forms":        true,
+	"triggers":            true,
+	"user_fields":         true,
+	"views":               true,
+}
+
+// reorderKeys holds the key of the ID list which the reorder endpoint of a collection takes
+var reorderKeys = map[string]string{
+	"triggers":      "trigger_ids",
+	"slas/policies": "sla_policy_ids",
+	"ticket_forms":  "ticket_form_ids",
+}
+
+var idPattern = regexp.MustCompile(`^(\d+|01[0-9A-Z]{24})$`)
+
+// variantsPattern matches the variants of a dynamic content item, and one variant of it
+var variantsPattern = regexp.MustCompile(`^dynamic_content/items/(\d+)/variants(?:/(\d+))?$`)
+
+var placeholderPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Server is a fake Zendesk API. Its API URL is URL + "/api/v2".
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int64
+	collections map[string][]Object
+	uploads     map[string][]int64
+}
+
+// NewServer starts a fake Zendesk API holding the system ticket fields of a new account
+func NewServer() *Server {
+	s := &Server{
+		nextID:      10000,
+		collections: map[string][]Object{},
+		uploads:     map[string][]int64{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	for i, f := range [][2]string{
+		{"subject", "Subject"}, {"description", "Description"}, {"status", "Status"}, {"tickettype", "Type"},
+		{"priority", "Priority"}, {"group", "Group"}, {"assignee", "Assignee"},
+	} {
+		s.Seed("ticket_fields", Object{
+			"type":      f[0],
+			"title":     f[1],
+			"position":  i + 1,
+			"removable": false,
+		})
+	}
+	s.Seed("locales", Object{"locale": "en-US", "name": "English"})
+	s.Seed("locales", Object{"locale": "de", "name": "Deutsch"})
+
+	return s
+}
+
+// Seed stores an object in a collection, e.g. "webhooks", as if it had been created
+// outside of Terraform. Objects without an id get a new one.
+func (s *Server) Seed(collection string, object Object) Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.create(collection, object)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, "Couldn't authenticate you")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v2/")
+	if path == r.URL.Path {
+		writeError(w, http.StatusNotFound, "InvalidEndpoint")
+		return
+	}
+	path = strings.TrimSuffix(path, ".json")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case path == "uploads" && r.Method == http.MethodPost:
+		s.upload(w, r)
+		return
+	case strings.HasPrefix(path, "uploads/") && r.Method == http.MethodDelete:
+		s.deleteUpload(w, strings.TrimPrefix(path, "uploads/"))
+		return
+	case strings.HasSuffix(path, "/reorder") && r.Method == http.MethodPut:
+		s.reorder(w, r, strings.TrimSuffix(path, "/reorder"))
+		return
+	case strings.HasSuffix(path, "/update_many") && r.Method == http.MethodPut:
+		s.updateMany(w, r, strings.TrimSuffix(path, "/update_many"))
+		return
+	case strings.HasPrefix(path, "webhooks/") && strings.HasSuffix(path, "/signing_secret"):
+		s.signingSecret(w, strings.TrimSuffix(strings.TrimPrefix(path, "webhooks/"), "/signing_secret"))
+		return
+	case strings.HasPrefix(path, "ticket_forms/") && strings.HasSuffix(path, "/clone") && r.Method == http.MethodPost:
+		s.cloneTicketForm(w, strings.TrimSuffix(strings.TrimPrefix(path, "ticket_forms/"), "/clone"))
+		return
+	case path == "deleted_groups" && r.Method == http.MethodGet:
+		s.listDeleted(w, "groups")
+		return
+	case variantsPattern.MatchString(path):
+		m := variantsPattern.FindStringSubmatch(path)
+		s.variants(w, r, m[1], m[2])
+		return
+	}
+
+	collection, id := path, ""
+	if i := strings.LastIndex(path, "/"); i >= 0 && idPattern.MatchString(path[i+1:]) {
+		collection, id = path[:i], path[i+1:]
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		s.list(w, collection)
+	case id == "" && r.Method == http.MethodPost:
+		object, ok := readObject(w, r, singular(collection))
+		if ok {
+			writeJSON(w, http.StatusCreated, Object{singular(collection): s.create(collection, object)})
+		}
+	case id != "" && r.Method == http.MethodGet:
+		if object := s.find(collection, id); object != nil {
+			writeJSON(w, http.StatusOK, Object{singular(collection): object})
+		} else {
+			writeError(w, http.StatusNotFound, "RecordNotFound")
+		}
+	case id != "" && (r.Method == http.MethodPut || r.Method == http.MethodPatch):
+		object := s.find(collection, id)
+		if object == nil {
+			writeError(w, http.StatusNotFound, "RecordNotFound")
+			return
+		}
+		changes, ok := readObject(w, r, singular(collection))
+		if ok {
+			s.update(collection, object, changes)
+			writeJSON(w, http.StatusOK, Object{singular(collection): object})
+		}
+	case id != "" && r.Method == http.MethodDelete:
+		if s.delete(collection, id) {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			writeError(w, http.StatusNotFound, "RecordNotFound")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "InvalidEndpoint")
+	}
+}
+
+func (s *Server) newID(collection string) interface{} {
+	s.nextID++
+	if collection == "webhooks" {
+		return fmt.Sprintf("01FAKE%020d", s.nextID)
+	}
+	return s.nextID
+}
+
+func (s *Server) create(collection string, object Object) Object {
+	if id, ok := object["id"]; !ok || id == nil || id == float64(0) || id == "" {
+		object["id"] = s.newID(collection)
+	}
+	object["url"] = fmt.Sprintf("%s/api/v2/%s/%v.json", s.URL, collection, object["id"])
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	object["created_at"] = now
+	object["updated_at"] = now
+
+	if _, ok := object["active"]; !ok && activeByDefault[collection] {
+		object["active"] = true
+	}
+	if _, ok := object["position"]; !ok {
+		if _, ordered := reorderKeys[collection]; ordered || collection == "automations" || collection == "macros" || collection == "views" {
+			object["position"] = len(s.collections[collection]) + 1
+		}
+	}
+
+	switch collection {
+	case "ticket_fields":
+		if _, ok := object["removable"]; !ok {
+			object["removable"] = true
+		}
+	case "oauth/clients":
+		object["secret"] = fmt.Sprintf("secret%d", s.nextID)
+	case "oauth/tokens":
+		object["full_token"] = fmt.Sprintf("token%d", s.nextID)
+		object["token"] = fmt.Sprintf("%d", s.nextID)
+	case "dynamic_content/items":
+		name, _ := object["name"].(string)
+		object["placeholder"] = fmt.Sprintf("{{dc.%s}}", strings.Trim(placeholderPattern.ReplaceAllString(strings.ToLower(name), "_"), "_"))
+	}
+	s.assignNestedIDs(object)
+	if collection == "views" {
+		viewExecution(object)
+	}
+
+	s.collections[collection] = append(s.collections[collection], object)
+	return object
+}
+
+// assignNestedIDs gives IDs to new field options and dynamic content variants
+func (s *Server) assignNestedIDs(object Object) {
+	for _, key := range []string{"custom_field_options", "variants"} {
+		list, _ := object[key].([]interface{})
+		for _, e := range list {
+			if nested, ok := e.(map[string]interface{}); ok {
+				if id, ok := nested["id"]; !ok || id == nil || id == float64(0) {
+					s.nextID++
+					nested["id"] = s.nextID
+				}
+			}
+		}
+	}
+}
+
+func (s *Server) update(collection string, object, changes Object) {
+	for k, v := range changes {
+		if k == "id" || k == "url" || k == "created_at" {
+			continue
+		}
+		object[k] = v
+	}
+	object["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+	s.assignNestedIDs(object)
+	if collection == "views" {
+		viewExecution(object)
+	}
+}
+
+// viewExecution turns the output which views are written with into the
+// execution they are read with, whose columns are objects
+func viewExecution(view Object) {
+	output, ok := view["output"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(view, "output")
+
+	execution := Object{}
+	for k, v := range output {
+		execution[k] = v
+	}
+	columns, _ := output["columns"].([]interface{})
+	executionColumns := []interface{}{}
+	for _, c := range columns {
+		executionColumns = append(executionColumns, Object{"id": c, "title": fmt.Sprintf("%v", c)})
+	}
+	execution["columns"] = executionColumns
+	view["execution"] = execution
+}
+
+func (s *Server) find(collection, id string) Object {
+	for _, object := range s.collections[collection] {
+		if fmt.Sprintf("%v", object["id"]) == id {
+			return object
+		}
+	}
+	return nil
+}
+
+func (s *Server) delete(collection, id string) bool {
+	objects := s.collections[collection]
+	for i, object := range objects {
+		if fmt.Sprintf("%v", object["id"]) != id {
+			continue
+		}
+
+		if fields, ok := softDeleted[collection]; ok {
+			for k, v := range fields {
+				object[k] = v
+			}
+		} else {
+			s.collections[collection] = append(objects[:i:i], objects[i+1:]...)
+		}
+		return true
+	}
+	return false
+}
+
+func (s *Server) list(w http.ResponseWriter, collection string) {
+	objects := []Object{}
+	for _, object := range s.collections[collection] {
+		if object["deleted"] == true {
+			continue
+		}
+		objects = append(objects, object)
+	}
+
+	writeList(w, plural(collection), objects)
+}
+
+// listDeleted serves the objects of a collection which were deleted, as
+// listed by Zendesk at /api/v2/deleted_<collection>
+func (s *Server) listDeleted(w http.ResponseWriter, collection string) {
+	objects := []Object{}
+	for _, object := range s.collections[collection] {
+		if object["deleted"] == true {
+			objects = append(objects, object)
+		}
+	}
+
+	writeList(w, "deleted_"+plural(collection), objects)
+}
+
+func writeList(w http.ResponseWriter, key string, objects []Object) {
+	writeJSON(w, http.StatusOK, Object{
+		key:             objects,
+		"next_page":     nil,
+		"previous_page": nil,
+		"count":         len(objects),
+		"meta":          Object{"has_more": false},
+	})
+}
+
+func (s *Server) reorder(w http.ResponseWriter, r *http.Request, collection string) {
+	var data map[string][]json.Number
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for i, id := range data[reorderKeys[collection]] {
+		if object := s.find(collection, id.String()); object != nil {
+			object["position"] = i + 1
+		}
+	}
+	writeJSON(w, http.StatusOK, Object{plural(collection): s.collections[collection]})
+}
+
+func (s *Server) updateMany(w http.ResponseWriter, r *http.Request, collection string) {
+	var data map[string][]Object
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, changes := range data[plural(collection)] {
+		if object := s.find(collection, fmt.Sprintf("%v", changes["id"])); object != nil {
+			s.update(collection, object, changes)
+		}
+	}
+	writeJSON(w, http.StatusOK, Object{plural(collection): s.collections[collection]})
+}
+
+// cloneTicketForm copies a ticket form, including its fields and conditions
+func (s *Server) cloneTicketForm(w http.ResponseWriter, id string) {
+	form := s.find("ticket_forms", id)
+	if form == nil {
+		writeError(w, http.StatusNotFound, "RecordNotFound")
+		return
+	}
+
+	clone := Object{}
+	for k, v := range form {
+		clone[k] = v
+	}
+	delete(clone, "id")
+	delete(clone, "position")
+	clone["name"] = fmt.Sprintf("%v (clone)", form["name"])
+	clone["default"] = false
+	writeJSON(w, http.StatusOK, Object{"ticket_form": s.create("ticket_forms", clone)})
+}
+
+// variants serves the variants of a dynamic content item, which are stored in
+// the item. Only one variant of an item is the default, and it can't be deleted.
+func (s *Server) variants(w http.ResponseWriter, r *http.Request, itemID, id string) {
+	item := s.find("dynamic_content/items", itemID)
+	if item == nil {
+		writeError(w, http.StatusNotFound, "RecordNotFound")
+		return
+	}
+	variants, _ := item["variants"].([]interface{})
+
+	index := -1
+	for i, v := range variants {
+		if variant, ok := v.(map[string]interface{}); ok && fmt.Sprintf("%v", variant["id"]) == id {
+			index = i
+		}
+	}
+	if id != "" && index < 0 {
+		writeError(w, http.StatusNotFound, "RecordNotFound")
+		return
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, Object{"variants": variants})
+	case id == "" && r.Method == http.MethodPost:
+		variant, ok := readObject(w, r, "variant")
+		if !ok {
+			return
+		}
+		s.nextID++
+		variant["id"] = s.nextID
+		variant["url"] = fmt.Sprintf("%s/api/v2/dynamic_content/items/%s/variants/%d.json", s.URL, itemID, s.nextID)
+		item["variants"] = append(variants, variant)
+		setDefaultVariant(item, variant)
+		writeJSON(w, http.StatusCreated, Object{"variant": variant})
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, Object{"variant": variants[index]})
+	case r.Method == http.MethodPut:
+		changes, ok := readObject(w, r, "variant")
+		if !ok {
+			return
+		}
+		variant := variants[index].(map[string]interface{})
+		for k, v := range changes {
+			if k != "id" && k != "url" {
+				variant[k] = v
+			}
+		}
+		setDefaultVariant(item, variant)
+		writeJSON(w, http.StatusOK, Object{"variant": variant})
+	case r.Method == http.MethodDelete:
+		if variants[index].(map[string]interface{})["default"] == true {
+			writeError(w, http.StatusUnprocessableEntity, "The default variant can't be deleted")
+			return
+		}
+		item["variants"] = append(variants[:index:index], variants[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "InvalidEndpoint")
+	}
+}
+
+// setDefaultVariant makes variant the only default variant of item if it is set as default
+func setDefaultVariant(item, variant Object) {
+	if variant["default"] != true {
+		return
+	}
+
+	variants, _ := item["variants"].([]interface{})
+	for _, v := range variants {
+		if other, ok := v.(map[string]interface{}); ok && fmt.Sprintf("%v", other["id"]) != fmt.Sprintf("%v", variant["id"]) {
+			other["default"] = false
+		}
+	}
+	item["default_locale_id"] = variant["locale_id"]
+}
+
+func (s *Server) signingSecret(w http.ResponseWriter, id string) {
+	if s.find("webhooks", id) == nil {
+		writeError(w, http.StatusNotFound, "RecordNotFound")
+		return
+	}
+	writeJSON(w, http.StatusOK, Object{"signing_secret": Object{"algorithm": "SHA256", "secret": "fake-secret-" + id}})
+}
+
+// upload stores the request body as an attachment of a new or existing upload
+func (s *Server) upload(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		s.nextID++
+		token = fmt.Sprintf("upload%d", s.nextID)
+	}
+
+	fileName := r.URL.Query().Get("filename")
+	attachment := s.create("attachments", Object{
+		"file_name":    fileName,
+		"content_type": r.Header.Get("Content-Type"),
+		"size":         len(body),
+		"inline":       false,
+	})
+	attachment["content_url"] = fmt.Sprintf("%s/attachments/token/%s/?name=%s", s.URL, token, fileName)
+	s.uploads[token] = append(s.uploads[token], attachment["id"].(int64))
+
+	var attachments []Object
+	for _, id := range s.uploads[token] {
+		attachments = append(attachments, s.find("attachments", strconv.FormatInt(id, 10)))
+	}
+	writeJSON(w, http.StatusCreated, Object{"upload": Object{
+		"token":       token,
+		"attachment":  attachment,
+		"attachments": attachments,
+	}})
+}
+
+func (s *Server) deleteUpload(w http.ResponseWriter, token string) {
+	ids, ok := s.uploads[token]
+	if !ok {
+		writeError(w, http.StatusNotFound, "RecordNotFound")
+		return
+	}
+
+	for _, id := range ids {
+		s.delete("attachments", strconv.FormatInt(id, 10))
+	}
+	delete(s.uploads, token)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readObject decodes the object under key from the request body
+func readObject(w http.ResponseWriter, r *http.Request, key string) (Object, bool) {
+	var data map[string]Object
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	object, ok := data[key]
+	if !ok || object == nil {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("request body has no %s", key))
+		return nil, false
+	}
+	return object, true
+}
+
+func plural(collection string) string {
+	if keys, ok := envelopes[collection]; ok {
+		return keys[0]
+	}
+	return collection[strings.LastIndex(collection, "/")+1:]
+}
+
+func singular(collection string) string {
+	if keys, ok := envelopes[collection]; ok {
+		return keys[1]
+	}
+
+	name := plural(collection)
+	if strings.HasSuffix(name, "ies") {
+		return strings.TrimSuffix(name, "ies") + "y"
+	}
+	return strings.TrimSuffix(name, "s")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, description string) {
+	writeJSON(w, status, Object{"error": http.StatusText(status), "description": description})
+}
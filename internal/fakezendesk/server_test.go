@@ -0,0 +1,330 @@
+package fakezendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+func newTestClient(t *testing.T) (*Server, *zendesk.Client) {
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	c, err := zendesk.NewClient(nil)
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	if err := c.SetEndpointURL(s.URL + "/api/v2"); err != nil {
+		t.Fatalf("could not set endpoint: %v", err)
+	}
+	c.SetCredential(zendesk.NewBearerTokenCredential("fake"))
+
+	return s, c
+}
+
+func TestServerCRUD(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	trigger, err := c.CreateTrigger(ctx, zendesk.Trigger{Title: "Escalate VIP"})
+	if err != nil {
+		t.Fatalf("CreateTrigger returned an error: %v", err)
+	}
+	if trigger.ID == 0 || !trigger.Active || trigger.Position != 1 {
+		t.Fatalf("created trigger %v should have had an id, been active and had position 1", trigger)
+	}
+
+	trigger.Title = "Escalate VIP customers"
+	_, err = c.UpdateTrigger(ctx, trigger.ID, trigger)
+	if err != nil {
+		t.Fatalf("UpdateTrigger returned an error: %v", err)
+	}
+
+	trigger, err = c.GetTrigger(ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetTrigger returned an error: %v", err)
+	}
+	if trigger.Title != "Escalate VIP customers" {
+		t.Fatalf("trigger had title %s. should have been Escalate VIP customers", trigger.Title)
+	}
+
+	err = c.DeleteTrigger(ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("DeleteTrigger returned an error: %v", err)
+	}
+
+	_, err = c.GetTrigger(ctx, trigger.ID)
+	if zdErr, ok := err.(zendesk.Error); !ok || zdErr.Status() != http.StatusNotFound {
+		t.Fatalf("GetTrigger of a deleted trigger returned %v. should have been not found", err)
+	}
+}
+
+func TestServerSoftDelete(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	group, err := c.CreateGroup(ctx, zendesk.Group{Name: "Tier 2"})
+	if err != nil {
+		t.Fatalf("CreateGroup returned an error: %v", err)
+	}
+
+	err = c.DeleteGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("DeleteGroup returned an error: %v", err)
+	}
+
+	group, err = c.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("GetGroup of a deleted group returned an error: %v", err)
+	}
+	if !group.Deleted {
+		t.Fatalf("deleted group should have been marked as deleted")
+	}
+
+	groups, _, err := c.GetGroups(ctx, nil)
+	if err != nil {
+		t.Fatalf("GetGroups returned an error: %v", err)
+	}
+	if len(groups) != 0 {
+		t.Fatalf("GetGroups returned %v. deleted groups should not have been listed", groups)
+	}
+
+	body, err := c.Get(ctx, "/deleted_groups.json")
+	if err != nil {
+		t.Fatalf("listing deleted groups returned an error: %v", err)
+	}
+	var deleted struct {
+		DeletedGroups []zendesk.Group `json:"deleted_groups"`
+	}
+	if err := json.Unmarshal(body, &deleted); err != nil {
+		t.Fatalf("could not decode the deleted groups %s: %v", body, err)
+	}
+	if len(deleted.DeletedGroups) != 1 || deleted.DeletedGroups[0].ID != group.ID {
+		t.Fatalf("deleted groups were %s. should have listed group %d", body, group.ID)
+	}
+}
+
+func TestServerSystemFields(t *testing.T) {
+	_, c := newTestClient(t)
+
+	fields, _, err := c.GetTicketFields(context.Background())
+	if err != nil {
+		t.Fatalf("GetTicketFields returned an error: %v", err)
+	}
+
+	for _, f := range fields {
+		if f.Type == "assignee" && !f.Removable {
+			return
+		}
+	}
+	t.Fatalf("ticket fields %v should have included the assignee system field", fields)
+}
+
+func TestServerFieldOptions(t *testing.T) {
+	_, c := newTestClient(t)
+
+	field, err := c.CreateTicketField(context.Background(), zendesk.TicketField{
+		Type:  "tagger",
+		Title: "Plan",
+		CustomFieldOptions: []zendesk.CustomFieldOption{
+			{Name: "Free", Value: "free"},
+			{Name: "Paid", Value: "paid"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateTicketField returned an error: %v", err)
+	}
+
+	for _, o := range field.CustomFieldOptions {
+		if o.ID == 0 {
+			t.Fatalf("option %v should have had an id", o)
+		}
+	}
+}
+
+func TestServerWebhooks(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+
+	s.Seed("webhooks", Object{"id": "1234", "name": "Seeded"})
+	seeded, err := c.GetWebhook(ctx, "1234")
+	if err != nil {
+		t.Fatalf("GetWebhook of a seeded webhook returned an error: %v", err)
+	}
+	if seeded.Name != "Seeded" {
+		t.Fatalf("seeded webhook had name %s. should have been Seeded", seeded.Name)
+	}
+
+	hook, err := c.CreateWebhook(ctx, &zendesk.Webhook{Name: "Slack", Endpoint: "https://example.com"})
+	if err != nil {
+		t.Fatalf("CreateWebhook returned an error: %v", err)
+	}
+	if len(hook.ID) != 26 {
+		t.Fatalf("webhook id %s should have had the length of a Zendesk webhook id", hook.ID)
+	}
+
+	err = c.DeleteWebhook(ctx, hook.ID)
+	if err != nil {
+		t.Fatalf("DeleteWebhook returned an error: %v", err)
+	}
+}
+
+func TestServerUploads(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	w := c.UploadAttachment(ctx, "notes.txt", "")
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("writing the upload returned an error: %v", err)
+	}
+	upload, err := w.Close()
+	if err != nil {
+		t.Fatalf("upload returned an error: %v", err)
+	}
+	if upload.Token == "" || upload.Attachment.FileName != "notes.txt" || upload.Attachment.Size != 5 {
+		t.Fatalf("upload %v should have had a token and the uploaded file", upload)
+	}
+
+	attachment, err := c.GetAttachment(ctx, upload.Attachment.ID)
+	if err != nil {
+		t.Fatalf("GetAttachment returned an error: %v", err)
+	}
+	if !strings.Contains(attachment.ContentURL, upload.Token) {
+		t.Fatalf("attachment url %s should have contained the upload token", attachment.ContentURL)
+	}
+
+	err = c.DeleteUpload(ctx, upload.Token)
+	if err != nil {
+		t.Fatalf("DeleteUpload returned an error: %v", err)
+	}
+
+	_, err = c.GetAttachment(ctx, upload.Attachment.ID)
+	if err == nil {
+		t.Fatalf("GetAttachment of a deleted upload should have returned an error")
+	}
+}
+
+func TestServerDynamicContentVariants(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	item, err := c.CreateDynamicContentItem(ctx, zendesk.DynamicContentItem{
+		Name:            "Ticket form: Snowboard Problem",
+		DefaultLocaleID: 1,
+		Variants:        []zendesk.DynamicContentVariant{{LocaleID: 1, Content: "Snowboard problem", Default: true}},
+	})
+	if err != nil {
+		t.Fatalf("CreateDynamicContentItem returned an error: %v", err)
+	}
+	if item.Placeholder != "{{dc.ticket_form_snowboard_problem}}" {
+		t.Fatalf("item had placeholder %s. should have been derived from its name", item.Placeholder)
+	}
+
+	path := fmt.Sprintf("/dynamic_content/items/%d/variants", item.ID)
+	body, err := c.Post(ctx, path+".json", map[string]interface{}{
+		"variant": zendesk.DynamicContentVariant{LocaleID: 8, Content: "Snowboard-Problem", Default: true},
+	})
+	if err != nil {
+		t.Fatalf("creating a variant returned an error: %v", err)
+	}
+	var created struct {
+		Variant zendesk.DynamicContentVariant `json:"variant"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil || created.Variant.ID == 0 {
+		t.Fatalf("created variant %s should have had an id: %v", body, err)
+	}
+
+	err = c.Delete(ctx, fmt.Sprintf("%s/%d.json", path, item.Variants[0].ID))
+	if err != nil {
+		t.Fatalf("deleting the variant which is no longer the default returned an error: %v", err)
+	}
+	if err := c.Delete(ctx, fmt.Sprintf("%s/%d.json", path, created.Variant.ID)); err == nil {
+		t.Fatal("deleting the default variant should have returned an error")
+	}
+
+	item, err = c.GetDynamicContentItem(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("GetDynamicContentItem returned an error: %v", err)
+	}
+	if len(item.Variants) != 1 || item.Variants[0].ID != created.Variant.ID || item.DefaultLocaleID != 8 {
+		t.Fatalf("item %+v should only have had the created variant as default", item)
+	}
+}
+
+func TestServerTicketFormClone(t *testing.T) {
+	s, c := newTestClient(t)
+	ctx := context.Background()
+
+	form := s.Seed("ticket_forms", Object{"name": "Snowboard Problem", "ticket_field_ids": []interface{}{1, 2}})
+
+	body, err := c.Post(ctx, fmt.Sprintf("/ticket_forms/%v/clone.json", form["id"]), struct{}{})
+	if err != nil {
+		t.Fatalf("cloning the ticket form returned an error: %v", err)
+	}
+	var clone struct {
+		TicketForm struct {
+			ID             int64   `json:"id"`
+			TicketFieldIDs []int64 `json:"ticket_field_ids"`
+		} `json:"ticket_form"`
+	}
+	if err := json.Unmarshal(body, &clone); err != nil {
+		t.Fatalf("could not decode the clone %s: %v", body, err)
+	}
+	if clone.TicketForm.ID == 0 || fmt.Sprintf("%d", clone.TicketForm.ID) == fmt.Sprintf("%v", form["id"]) || len(clone.TicketForm.TicketFieldIDs) != 2 {
+		t.Fatalf("clone %s should have been a new form with the fields of the original", body)
+	}
+}
+
+// The views of the API are written with output, but read with execution
+func TestServerViewExecution(t *testing.T) {
+	_, c := newTestClient(t)
+	ctx := context.Background()
+
+	body, err := c.Post(ctx, "/views.json", map[string]interface{}{
+		"view": map[string]interface{}{
+			"title":  "Open tickets",
+			"output": map[string]interface{}{"columns": []string{"status", "subject"}, "sort_by": "status"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("creating a view returned an error: %v", err)
+	}
+
+	var view struct {
+		View struct {
+			Output    interface{} `json:"output"`
+			Execution struct {
+				SortBy  string `json:"sort_by"`
+				Columns []struct {
+					ID string `json:"id"`
+				} `json:"columns"`
+			} `json:"execution"`
+		} `json:"view"`
+	}
+	if err := json.Unmarshal(body, &view); err != nil {
+		t.Fatalf("could not decode the view %s: %v", body, err)
+	}
+	if view.View.Output != nil || view.View.Execution.SortBy != "status" || len(view.View.Execution.Columns) != 2 || view.View.Execution.Columns[1].ID != "subject" {
+		t.Fatalf("view %s should have been returned with execution columns instead of output", body)
+	}
+}
+
+func TestServerRequiresCredentials(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/api/v2/triggers.json")
+	if err != nil {
+		t.Fatalf("request returned an error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("request without credentials had status %d. should have been %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/nukosuke/terraform-provider-zendesk/zendesk"
 )
@@ -9,10 +8,11 @@ import (
 // Generate provider document
 //go:generate go run -mod=mod github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
+// version is set by the goreleaser configuration to the release version of the binary
+var version = "dev"
+
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return zendesk.Provider()
-		},
+		ProviderFunc: zendesk.New(version),
 	})
 }
@@ -0,0 +1,107 @@
+package zendesk
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+const accountKey = "account"
+
+// providerMeta is the meta of the provider: the client of the top-level
+// account and the provider settings which resources use when planning.
+// CustomizeDiff functions receive it for the selected account, other
+// resource functions only receive the client.
+type providerMeta struct {
+	*newClient.Client
+
+	// strictLiquid makes plans fail on problems found in Liquid placeholders
+	strictLiquid bool
+}
+
+// withAccount adds the optional account attribute to r and wraps its functions,
+// so that they receive the client of the selected account as meta.
+// Resource functions themselves stay unaware of multiple accounts.
+func withAccount(r *schema.Resource, isDataSource bool) *schema.Resource {
+	r.Schema[accountKey] = &schema.Schema{
+		Description: "Name of the entry in the provider `accounts` block to use. Defaults to the account configured at the top level of the provider.",
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    !isDataSource,
+	}
+
+	r.CreateContext = wrapCRUD(r.CreateContext)
+	r.ReadContext = wrapCRUD(r.ReadContext)
+	r.UpdateContext = wrapCRUD(r.UpdateContext)
+	r.DeleteContext = wrapCRUD(r.DeleteContext)
+
+	if r.CustomizeDiff != nil {
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			zd, err := accountClient(meta, d.Get(accountKey).(string))
+			if err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, &providerMeta{
+				Client:       zd,
+				strictLiquid: meta.(*providerMeta).strictLiquid,
+			})
+		}
+	}
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		stateContext := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if err := parseAccountImportID(d, meta); err != nil {
+				return nil, err
+			}
+
+			zd, err := accountClient(meta, d.Get(accountKey).(string))
+			if err != nil {
+				return nil, err
+			}
+			return stateContext(ctx, d, zd)
+		}
+	}
+
+	return r
+}
+
+func wrapCRUD(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		zd, err := accountClient(meta, d.Get(accountKey).(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, zd)
+	}
+}
+
+// accountClient returns the client of the named account from the provider meta
+func accountClient(meta interface{}, name string) (*newClient.Client, error) {
+	return meta.(*providerMeta).Account(name)
+}
+
+// parseAccountImportID handles import IDs of the form <account>/<id>, which
+// import an object of a named account. The prefix is only stripped when it
+// names a configured account, so IDs containing slashes keep working.
+func parseAccountImportID(d *schema.ResourceData, meta interface{}) error {
+	name, id, found := strings.Cut(d.Id(), "/")
+	if !found {
+		return nil
+	}
+
+	if _, ok := meta.(*providerMeta).Accounts[name]; !ok {
+		return nil
+	}
+
+	d.SetId(id)
+	return d.Set(accountKey, name)
+}
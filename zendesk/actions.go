@@ -0,0 +1,358 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Ways to write the value of an action block. Exactly one of them is used per action.
+const (
+	actionValue               = "value"
+	actionValueList           = "value_list"
+	actionNotificationUser    = "notification_user"
+	actionNotificationWebhook = "notification_webhook"
+)
+
+var actionValueKeys = []string{actionValue, actionValueList, actionNotificationUser, actionNotificationWebhook}
+
+// ruleAction is the field and value of an action of a trigger, automation or macro
+type ruleAction struct {
+	Field string
+	Value interface{}
+}
+
+// actionSchema returns the action block schema shared by triggers, automations and macros.
+// When alternatives are given, action blocks are optional as long as one of the
+// alternative blocks is set.
+func actionSchema(desc string, alternatives ...string) *schema.Schema {
+	s := &schema.Schema{
+		Description: desc,
+		Type:        schema.TypeSet,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Description: "The name of a ticket field to modify.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				actionValue: {
+					Description:      "The new value of the field. A jsonencode'ed list is accepted for backwards compatibility, use `value_list` instead.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateLiquid(),
+				},
+				actionValueList: {
+					Description: "The new value of the field, for actions which take a list.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: validateLiquid(),
+					},
+				},
+				actionNotificationUser: {
+					Description: "The email sent by `notification_user` and `notification_group` actions.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"recipient": {
+								Description: "The user or group ID, or a placeholder such as `requester_id` or `current_user`.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"subject": {
+								Description:      "The subject of the email.",
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validateLiquid(),
+							},
+							"body": {
+								Description:      "The body of the email.",
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validateLiquid(),
+							},
+						},
+					},
+				},
+				actionNotificationWebhook: {
+					Description: "The request sent by `notification_webhook` actions.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"recipient": {
+								Description: "The ID of the webhook.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"body": {
+								Description:      "The body of the request.",
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validateLiquid(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if len(alternatives) > 0 {
+		s.Optional = true
+		s.AtLeastOneOf = append([]string{"action"}, alternatives...)
+	} else {
+		s.Required = true
+	}
+
+	return s
+}
+
+// setActionValueKeys returns the value attributes which are set in an action block
+func setActionValueKeys(action map[string]interface{}) []string {
+	var keys []string
+	for _, k := range actionValueKeys {
+		switch v := action[k].(type) {
+		case string:
+			if v != "" {
+				keys = append(keys, k)
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
+
+// expandActionValue returns the value sent to Zendesk for an action block
+func expandActionValue(action map[string]interface{}) (interface{}, error) {
+	keys := setActionValueKeys(action)
+	if len(keys) > 1 {
+		return nil, fmt.Errorf("action %s can only set one of %s, got %s", action["field"], strings.Join(actionValueKeys, ", "), strings.Join(keys, " and "))
+	}
+
+	if len(keys) == 0 {
+		return "", nil
+	}
+
+	switch keys[0] {
+	case actionValueList:
+		return action[actionValueList].([]interface{}), nil
+	case actionNotificationUser:
+		n := action[actionNotificationUser].([]interface{})[0].(map[string]interface{})
+		return []interface{}{n["recipient"], n["subject"], n["body"]}, nil
+	case actionNotificationWebhook:
+		n := action[actionNotificationWebhook].([]interface{})[0].(map[string]interface{})
+		return []interface{}{n["recipient"], n["body"]}, nil
+	}
+
+	// If the action value is a list, unmarshal it
+	value := action[actionValue].(string)
+	if strings.HasPrefix(value, "[") {
+		var listValue interface{}
+		err := json.Unmarshal([]byte(value), &listValue)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling action value: %s", err)
+		}
+		return listValue, nil
+	}
+
+	return value, nil
+}
+
+// expandActions converts the action blocks of a resource into actions sent to Zendesk
+func expandActions(v interface{}) ([]ruleAction, error) {
+	var actions []ruleAction
+	for _, a := range actionBlocks(v) {
+		action, ok := a.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("could not parse action %v", a)
+		}
+
+		value, err := expandActionValue(action)
+		if err != nil {
+			return nil, err
+		}
+
+		actions = append(actions, ruleAction{
+			Field: action["field"].(string),
+			Value: value,
+		})
+	}
+
+	return actions, nil
+}
+
+// flattenActions converts actions read from Zendesk into action blocks.
+// prior holds the action blocks currently in state. Each value is written in
+// the same form as the prior action with that field, and lists which Zendesk
+// returns in a different order keep their prior order, so that reads don't
+// produce diffs. Without prior state, the typed blocks are preferred.
+func flattenActions(actions []ruleAction, prior interface{}) ([]map[string]interface{}, error) {
+	priorForms := map[string]string{}
+	priorLists := map[string][][]string{}
+	for _, a := range actionBlocks(prior) {
+		action, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		field, _ := action["field"].(string)
+		keys := setActionValueKeys(action)
+		if len(keys) == 1 {
+			priorForms[field] = keys[0]
+		}
+		if list, ok := action[actionValueList].([]interface{}); ok && len(list) > 0 {
+			priorLists[field] = append(priorLists[field], interfaceSliceToStrings(list))
+		}
+	}
+
+	var blocks []map[string]interface{}
+	for _, action := range actions {
+		m := map[string]interface{}{
+			"field":                   action.Field,
+			actionValue:               "",
+			actionValueList:           []string{},
+			actionNotificationUser:    []map[string]interface{}{},
+			actionNotificationWebhook: []map[string]interface{}{},
+		}
+
+		list, isList := action.Value.([]interface{})
+		if !isList {
+			m[actionValue] = actionValueString(action.Value)
+			blocks = append(blocks, m)
+			continue
+		}
+
+		values := interfaceSliceToStrings(list)
+		form := priorForms[action.Field]
+		switch {
+		case form == actionValue:
+			// If it's a list, marshal it to a string
+			tmp, err := json.Marshal(action.Value)
+			if err != nil {
+				return nil, err
+			}
+			m[actionValue] = string(tmp)
+		case (form == "" || form == actionNotificationUser) && isNotificationUserField(action.Field) && len(values) == 3:
+			m[actionNotificationUser] = []map[string]interface{}{{
+				"recipient": values[0],
+				"subject":   values[1],
+				"body":      values[2],
+			}}
+		case (form == "" || form == actionNotificationWebhook) && action.Field == "notification_webhook" && len(values) == 2:
+			m[actionNotificationWebhook] = []map[string]interface{}{{
+				"recipient": values[0],
+				"body":      values[1],
+			}}
+		default:
+			m[actionValueList] = normalizeActionList(values, priorLists[action.Field])
+		}
+
+		blocks = append(blocks, m)
+	}
+
+	return blocks, nil
+}
+
+// validateActionsDiff rejects action blocks which set more than one kind of value
+func validateActionsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, a := range actionBlocks(d.Get("action")) {
+		action := a.(map[string]interface{})
+		keys := setActionValueKeys(action)
+		if len(keys) > 1 {
+			return fmt.Errorf("action %s can only set one of %s, got %s", action["field"], strings.Join(actionValueKeys, ", "), strings.Join(keys, " and "))
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		field, _ := action["field"].(string)
+		switch {
+		case keys[0] == actionNotificationUser && !isNotificationUserField(field):
+			return fmt.Errorf("notification_user can only be used by notification_user and notification_group actions, not %s", field)
+		case keys[0] == actionNotificationWebhook && field != "notification_webhook":
+			return fmt.Errorf("notification_webhook can only be used by notification_webhook actions, not %s", field)
+		}
+	}
+
+	return nil
+}
+
+func isNotificationUserField(field string) bool {
+	return field == "notification_user" || field == "notification_group"
+}
+
+// actionBlocks returns the action blocks of v, which is a set when read from
+// resource data and a list in unit tests
+func actionBlocks(v interface{}) []interface{} {
+	switch blocks := v.(type) {
+	case *schema.Set:
+		return blocks.List()
+	case []interface{}:
+		return blocks
+	case []map[string]interface{}:
+		list := make([]interface{}, len(blocks))
+		for i, b := range blocks {
+			list[i] = b
+		}
+		return list
+	}
+	return nil
+}
+
+// actionValueString formats a scalar action value as it is written in configuration
+func actionValueString(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case nil:
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func interfaceSliceToStrings(list []interface{}) []string {
+	values := make([]string, len(list))
+	for i, v := range list {
+		values[i] = actionValueString(v)
+	}
+	return values
+}
+
+// normalizeActionList returns the prior list with the same elements as values if
+// there is one, so that Zendesk reordering list elements doesn't cause a diff
+func normalizeActionList(values []string, prior [][]string) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+
+	for _, p := range prior {
+		if len(p) != len(values) {
+			continue
+		}
+
+		sortedPrior := append([]string(nil), p...)
+		sort.Strings(sortedPrior)
+		if strings.Join(sortedPrior, "\x00") == strings.Join(sorted, "\x00") {
+			return p
+		}
+	}
+
+	return values
+}
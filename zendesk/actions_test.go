@@ -0,0 +1,128 @@
+package zendesk
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+func TestExpandActionValue(t *testing.T) {
+	cases := []struct {
+		action   map[string]interface{}
+		expected interface{}
+	}{
+		{
+			action:   map[string]interface{}{"field": "status", "value": "solved"},
+			expected: "solved",
+		},
+		{
+			action:   map[string]interface{}{"field": "notification_user", "value": `["requester_id","Hi","Body"]`},
+			expected: []interface{}{"requester_id", "Hi", "Body"},
+		},
+		{
+			action:   map[string]interface{}{"field": "cc", "value_list": []interface{}{"jane@example.com", "joe@example.com"}},
+			expected: []interface{}{"jane@example.com", "joe@example.com"},
+		},
+		{
+			action: map[string]interface{}{
+				"field": "notification_user",
+				"notification_user": []interface{}{
+					map[string]interface{}{"recipient": "requester_id", "subject": "Hi", "body": "Body"},
+				},
+			},
+			expected: []interface{}{"requester_id", "Hi", "Body"},
+		},
+		{
+			action: map[string]interface{}{
+				"field": "notification_webhook",
+				"notification_webhook": []interface{}{
+					map[string]interface{}{"recipient": "01GB4TVBGQ", "body": `{"id":"{{ticket.id}}"}`},
+				},
+			},
+			expected: []interface{}{"01GB4TVBGQ", `{"id":"{{ticket.id}}"}`},
+		},
+	}
+
+	for _, c := range cases {
+		v, err := expandActionValue(c.action)
+		if err != nil {
+			t.Fatalf("expandActionValue(%v) returned an error: %v", c.action, err)
+		}
+		if !reflect.DeepEqual(v, c.expected) {
+			t.Fatalf("expandActionValue(%v) was %v. should have been %v", c.action, v, c.expected)
+		}
+	}
+
+	_, err := expandActionValue(map[string]interface{}{
+		"field":      "cc",
+		"value":      "jane@example.com",
+		"value_list": []interface{}{"jane@example.com"},
+	})
+	if err == nil {
+		t.Fatal("expandActionValue should have rejected an action with both value and value_list")
+	}
+}
+
+func TestFlattenActions(t *testing.T) {
+	actions := []ruleAction{
+		{Field: "status", Value: "solved"},
+		{Field: "notification_user", Value: []interface{}{"requester_id", "Hi", "Body"}},
+		{Field: "cc", Value: []interface{}{"joe@example.com", "jane@example.com"}},
+	}
+
+	// without prior state the typed blocks are used
+	blocks, err := flattenActions(actions, nil)
+	if err != nil {
+		t.Fatalf("flattenActions returned an error: %v", err)
+	}
+	if v := blocks[0]["value"]; v != "solved" {
+		t.Fatalf("status action had value %v. should have been solved", v)
+	}
+	notification := blocks[1]["notification_user"].([]map[string]interface{})
+	if len(notification) != 1 || notification[0]["subject"] != "Hi" {
+		t.Fatalf("notification_user action had notification %v. should have had subject Hi", notification)
+	}
+	if v := blocks[2]["value_list"]; !reflect.DeepEqual(v, []string{"joe@example.com", "jane@example.com"}) {
+		t.Fatalf("cc action had value_list %v. should have been [joe@example.com jane@example.com]", v)
+	}
+
+	// prior state keeps the jsonencode'ed form and the order of lists
+	prior := []interface{}{
+		map[string]interface{}{"field": "notification_user", "value": `["requester_id","Hi","Body"]`},
+		map[string]interface{}{"field": "cc", "value_list": []interface{}{"jane@example.com", "joe@example.com"}},
+	}
+	blocks, err = flattenActions(actions, prior)
+	if err != nil {
+		t.Fatalf("flattenActions returned an error: %v", err)
+	}
+	if v := blocks[1]["value"]; v != `["requester_id","Hi","Body"]` {
+		t.Fatalf("notification_user action had value %v. should have kept the jsonencode'ed form", v)
+	}
+	if v := blocks[2]["value_list"]; !reflect.DeepEqual(v, []string{"jane@example.com", "joe@example.com"}) {
+		t.Fatalf("cc action had value_list %v. should have kept the prior order [jane@example.com joe@example.com]", v)
+	}
+}
+
+func TestMarshalTriggerTypedActions(t *testing.T) {
+	trigger := zendesk.Trigger{
+		Title: "Notify requester",
+		Actions: []zendesk.TriggerAction{
+			{Field: "notification_user", Value: []interface{}{"requester_id", "Hi", "Body"}},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceZendeskTrigger().Schema, map[string]interface{}{})
+	if err := marshalTrigger(trigger, d); err != nil {
+		t.Fatalf("marshalTrigger returned an error: %v", err)
+	}
+
+	trg, err := unmarshalTrigger(d)
+	if err != nil {
+		t.Fatalf("unmarshalTrigger returned an error: %v", err)
+	}
+	if len(trg.Actions) != 1 || !reflect.DeepEqual(trg.Actions[0].Value, []interface{}{"requester_id", "Hi", "Body"}) {
+		t.Fatalf("trigger actions did not survive a round trip: %v", trg.Actions)
+	}
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// newCassetteClient returns a client of the API at baseURL sending requests through transport
+func newCassetteClient(t *testing.T, baseURL string, transport http.RoundTripper) *Client {
+	zd, err := zendesk.NewClient(&http.Client{Transport: transport})
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	if err := zd.SetEndpointURL(baseURL + "/api/v2"); err != nil {
+		t.Fatalf("could not set endpoint: %v", err)
+	}
+	zd.SetCredential(zendesk.NewBearerTokenCredential("xxx"))
+	return &Client{Client: *zd}
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "oauth.json")
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"token":{"id":1,"full_token":"abc123","url":"` + server.URL + `/api/v2/oauth/tokens/1.json"}}`))
+	}))
+	defer server.Close()
+
+	recorder, err := NewCassetteTransport(nil, path, CassetteRecord)
+	if err != nil {
+		t.Fatalf("NewCassetteTransport returned an error: %v", err)
+	}
+	c := newCassetteClient(t, server.URL, recorder)
+	if _, err := c.Post(context.Background(), "/oauth/tokens.json", map[string]interface{}{"token": map[string]interface{}{"client_id": 1}}); err != nil {
+		t.Fatalf("recorded request returned an error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cassette was not written: %v", err)
+	}
+	for _, s := range []string{"abc123", "Bearer", server.URL} {
+		if strings.Contains(string(data), s) {
+			t.Fatalf("cassette should not have contained %s:\n%s", s, data)
+		}
+	}
+	if !strings.Contains(string(data), `"path": "/api/v2/oauth/tokens.json"`) {
+		t.Fatalf("cassette did not contain the request path:\n%s", data)
+	}
+
+	player, err := NewCassetteTransport(nil, path, CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassetteTransport returned an error: %v", err)
+	}
+	c = newCassetteClient(t, server.URL, player)
+	server.Close()
+
+	body, err := c.Post(context.Background(), "/oauth/tokens.json", map[string]interface{}{"token": map[string]interface{}{"client_id": 1}})
+	if err != nil {
+		t.Fatalf("replayed request returned an error: %v", err)
+	}
+	var result struct {
+		Token struct {
+			ID int64 `json:"id"`
+		} `json:"token"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || result.Token.ID != 1 {
+		t.Fatalf("replayed response was %s. should have been the recorded one", body)
+	}
+
+	_, err = c.Post(context.Background(), "/oauth/tokens.json", nil)
+	if err == nil || !strings.Contains(err.Error(), "no interaction left") {
+		t.Fatalf("request after the end of the cassette returned %v. should have been an error", err)
+	}
+}
+
+func TestCassetteReplayMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.json")
+	err := os.WriteFile(path, []byte(`{"interactions":[{"method":"POST","path":"/api/v2/groups.json","request_body":{"group":{"name":"Tier 2"}},"status":201,"response_body":{"group":{"id":1}}}]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player, err := NewCassetteTransport(nil, path, CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassetteTransport returned an error: %v", err)
+	}
+	c := newCassetteClient(t, "https://example.zendesk.com", player)
+
+	_, err = c.Post(context.Background(), "/groups.json", map[string]interface{}{"group": map[string]interface{}{"name": "Tier 3"}})
+	if err == nil || !strings.Contains(err.Error(), "was recorded") {
+		t.Fatalf("request with a different body returned %v. should have been a mismatch", err)
+	}
+}
+
+func TestNewCassetteTransportMode(t *testing.T) {
+	if _, err := NewCassetteTransport(nil, "cassette.json", CassetteMode("rewind")); err == nil {
+		t.Fatalf("unknown cassette mode should have returned an error")
+	}
+	if _, err := NewCassetteTransport(nil, filepath.Join(t.TempDir(), "missing.json"), CassetteReplay); err == nil {
+		t.Fatalf("replaying a missing cassette should have returned an error")
+	}
+}
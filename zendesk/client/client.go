@@ -1,6 +1,7 @@
 package client
 
 import (
+	"fmt"
 	"net/url"
 
 	"github.com/google/go-querystring/query"
@@ -11,9 +12,26 @@ type (
 	Client struct {
 		// use struct embedding for extension
 		zendesk.Client
+
+		// Accounts holds the clients of additional named Zendesk instances
+		Accounts map[string]*Client
 	}
 )
 
+// Account returns the client of the named account. An empty name selects z itself.
+func (z *Client) Account(name string) (*Client, error) {
+	if name == "" {
+		return z, nil
+	}
+
+	c, ok := z.Accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("account %q is not configured in the provider accounts block", name)
+	}
+
+	return c, nil
+}
+
 // addOptions build query string
 func addOptions(s string, opts interface{}) (string, error) {
 	u, err := url.Parse(s)
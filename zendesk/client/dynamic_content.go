@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// DynamicContentVariantAPI changes the variants of a dynamic content item, which
+// zendesk.DynamicContentAPI only sets when the item is created
+type DynamicContentVariantAPI interface {
+	CreateDynamicContentVariant(ctx context.Context, itemID int64, variant zendesk.DynamicContentVariant) (zendesk.DynamicContentVariant, error)
+	UpdateDynamicContentVariant(ctx context.Context, itemID int64, variant zendesk.DynamicContentVariant) (zendesk.DynamicContentVariant, error)
+	DeleteDynamicContentVariant(ctx context.Context, itemID int64, id int64) error
+}
+
+// CreateDynamicContentVariant adds a variant to a dynamic content item
+// ref: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/#create-variant
+func (z *Client) CreateDynamicContentVariant(ctx context.Context, itemID int64, variant zendesk.DynamicContentVariant) (zendesk.DynamicContentVariant, error) {
+	var data, result struct {
+		Variant zendesk.DynamicContentVariant `json:"variant"`
+	}
+	data.Variant = variant
+
+	body, err := z.Post(ctx, fmt.Sprintf("/dynamic_content/items/%d/variants.json", itemID), data)
+	if err != nil {
+		return zendesk.DynamicContentVariant{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return zendesk.DynamicContentVariant{}, err
+	}
+	return result.Variant, nil
+}
+
+// UpdateDynamicContentVariant changes the content of a variant, or makes it the default one
+// ref: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/#update-variant
+func (z *Client) UpdateDynamicContentVariant(ctx context.Context, itemID int64, variant zendesk.DynamicContentVariant) (zendesk.DynamicContentVariant, error) {
+	var data, result struct {
+		Variant zendesk.DynamicContentVariant `json:"variant"`
+	}
+	data.Variant = variant
+
+	body, err := z.Put(ctx, fmt.Sprintf("/dynamic_content/items/%d/variants/%d.json", itemID, variant.ID), data)
+	if err != nil {
+		return zendesk.DynamicContentVariant{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return zendesk.DynamicContentVariant{}, err
+	}
+	return result.Variant, nil
+}
+
+// DeleteDynamicContentVariant deletes a variant which isn't the default one
+// ref: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/#delete-variant
+func (z *Client) DeleteDynamicContentVariant(ctx context.Context, itemID int64, id int64) error {
+	return z.Delete(ctx, fmt.Sprintf("/dynamic_content/items/%d/variants/%d.json", itemID, id))
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// DeletedGroupListResponse represents the response from listing deleted groups
+type DeletedGroupListResponse struct {
+	DeletedGroups []zendesk.Group `json:"deleted_groups"`
+	NextPage      string          `json:"next_page,omitempty"`
+	PreviousPage  string          `json:"previous_page,omitempty"`
+	Count         int64           `json:"count,omitempty"`
+}
+
+// DeletedGroupAPI interface for listing the groups Zendesk keeps after deletion
+type DeletedGroupAPI interface {
+	GetDeletedGroups(ctx context.Context) ([]zendesk.Group, error)
+}
+
+// GetDeletedGroups fetches all deleted groups. GetGroups only returns groups which aren't deleted.
+// ref: https://developer.zendesk.com/api-reference/ticketing/groups/groups/#list-deleted-groups
+func (z *Client) GetDeletedGroups(ctx context.Context) ([]zendesk.Group, error) {
+	var groups []zendesk.Group
+
+	for page := 1; ; page++ {
+		var result DeletedGroupListResponse
+
+		body, err := z.Get(ctx, fmt.Sprintf("/deleted_groups.json?page=%d&per_page=100", page))
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(body, &result)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range result.DeletedGroups {
+			group.Deleted = true
+			groups = append(groups, group)
+		}
+
+		if result.NextPage == "" || len(result.DeletedGroups) == 0 {
+			return groups, nil
+		}
+	}
+}
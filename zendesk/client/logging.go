@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "***"
+
+// sensitiveKeys are JSON keys whose values never appear in logs
+var sensitiveKeys = map[string]bool{
+	"password":       true,
+	"token":          true,
+	"full_token":     true,
+	"access_token":   true,
+	"refresh_token":  true,
+	"secret":         true,
+	"client_secret":  true,
+	"signing_secret": true,
+}
+
+// rateLimitHeaders are response headers which describe the remaining API quota
+// ref: https://developer.zendesk.com/api-reference/introduction/rate-limits/
+var rateLimitHeaders = []string{
+	"Retry-After",
+	"X-Rate-Limit",
+	"X-Rate-Limit-Remaining",
+	"Ratelimit-Limit",
+	"Ratelimit-Remaining",
+	"Ratelimit-Reset",
+	"Zendesk-Ratelimit-Tickets-Index",
+}
+
+// LoggingTransport is an http.RoundTripper which logs every API call with terraform-plugin-log.
+// Method, path, status, duration and rate limit headers are logged at DEBUG level,
+// JSON bodies at TRACE level with credentials redacted. Output is controlled by TF_LOG_PROVIDER.
+type LoggingTransport struct {
+	Base http.RoundTripper
+}
+
+// NewLoggingTransport wraps base with logging. If base is nil, http.DefaultTransport is used.
+func NewLoggingTransport(base http.RoundTripper) *LoggingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &LoggingTransport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+
+	if req.GetBody != nil && isJSON(req.Header) {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			tflog.Trace(ctx, "Zendesk API request body", map[string]interface{}{
+				"http_method": req.Method,
+				"http_path":   req.URL.Path,
+				"http_body":   RedactJSON(data),
+			})
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Zendesk API request failed", fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	for _, h := range rateLimitHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			fields["http_header_"+strings.ToLower(strings.ReplaceAll(h, "-", "_"))] = v
+		}
+	}
+	tflog.Debug(ctx, "Zendesk API request", fields)
+
+	if isJSON(resp.Header) {
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if err != nil {
+			return resp, err
+		}
+
+		tflog.Trace(ctx, "Zendesk API response body", map[string]interface{}{
+			"http_method": req.Method,
+			"http_path":   req.URL.Path,
+			"http_status": resp.StatusCode,
+			"http_body":   RedactJSON(data),
+		})
+	}
+
+	return resp, nil
+}
+
+func isJSON(h http.Header) bool {
+	return strings.HasPrefix(h.Get("Content-Type"), "application/json")
+}
+
+// RedactJSON returns data with the values of credential keys and the
+// authentication data of webhooks replaced. Invalid JSON is not logged at all.
+func RedactJSON(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "<non-JSON body omitted>"
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return "<non-JSON body omitted>"
+	}
+
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, child := range value {
+			switch child.(type) {
+			case map[string]interface{}, []interface{}:
+				// webhook authentication carries basic auth, bearer tokens or api keys in "data"
+				if auth, ok := child.(map[string]interface{}); ok && k == "authentication" && auth["data"] != nil {
+					auth["data"] = redacted
+				}
+				// envelopes such as {"token": {...}} are walked, not hidden
+				value[k] = redactValue(child)
+			default:
+				if sensitiveKeys[k] && child != nil && child != "" {
+					value[k] = redacted
+				}
+			}
+		}
+		return value
+	case []interface{}:
+		for i, child := range value {
+			value[i] = redactValue(child)
+		}
+		return value
+	default:
+		return v
+	}
+}
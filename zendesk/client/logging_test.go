@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransportLogsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("X-Rate-Limit-Remaining", "699")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"token":{"id":1,"full_token":"abcdef"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/api/v2/oauth/tokens.json", strings.NewReader(`{"token":{"client_id":1}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Basic c2VjcmV0")
+
+	c := &http.Client{Transport: NewLoggingTransport(nil)}
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("request returned an error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"token":{"id":1,"full_token":"abcdef"}}` {
+		t.Fatalf("response body was %s. should have been passed through unchanged", body)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("could not decode log output: %v", err)
+	}
+
+	var found bool
+	for _, entry := range entries {
+		if entry["@message"] != "Zendesk API request" {
+			continue
+		}
+		found = true
+
+		if v := entry["http_method"]; v != http.MethodPost {
+			t.Fatalf("logged method was %v. should have been POST", v)
+		}
+		if v := entry["http_path"]; v != "/api/v2/oauth/tokens.json" {
+			t.Fatalf("logged path was %v. should have been /api/v2/oauth/tokens.json", v)
+		}
+		if v := entry["http_status"]; v != float64(http.StatusCreated) {
+			t.Fatalf("logged status was %v. should have been 201", v)
+		}
+		if v := entry["http_header_x_rate_limit_remaining"]; v != "699" {
+			t.Fatalf("logged rate limit was %v. should have been 699", v)
+		}
+		if _, ok := entry["duration_ms"]; !ok {
+			t.Fatal("request duration was not logged")
+		}
+	}
+	if !found {
+		t.Fatalf("request was not logged. output was %v", entries)
+	}
+
+	if strings.Contains(output.String(), "abcdef") || strings.Contains(output.String(), "c2VjcmV0") {
+		t.Fatalf("credentials were logged: %s", output.String())
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    `{"user":{"name":"Jane","password":"hunter2"}}`,
+			expected: `{"user":{"name":"Jane","password":"***"}}`,
+		},
+		{
+			input:    `{"clients":[{"identifier":"app","secret":"s3cr3t"}]}`,
+			expected: `{"clients":[{"identifier":"app","secret":"***"}]}`,
+		},
+		{
+			input:    `{"webhook":{"authentication":{"type":"bearer_token","data":{"token":"abc"}}}}`,
+			expected: `{"webhook":{"authentication":{"data":"***","type":"bearer_token"}}}`,
+		},
+		{
+			input:    `{"token":{"token":""}}`,
+			expected: `{"token":{"token":""}}`,
+		},
+		{
+			input:    `not json`,
+			expected: `<non-JSON body omitted>`,
+		},
+	}
+
+	for _, c := range cases {
+		if v := RedactJSON([]byte(c.input)); v != c.expected {
+			t.Fatalf("RedactJSON(%s) was %s. should have been %s", c.input, v, c.expected)
+		}
+	}
+}
@@ -102,7 +102,6 @@ func (z *Client) UpdateMacro(ctx context.Context, id int64, form models.Macro) (
 	})
 
 	data.Macro = form
-	fmt.Printf("\nUpdating Macro position to %d\n", data.Macro.Position)
 	body, err := z.Put(ctx, fmt.Sprintf("/macros/%d.json", id), data)
 	if err != nil {
 		return models.Macro{}, err
@@ -133,8 +132,6 @@ func (z *Client) UpdateMacroPosition(ctx context.Context, id int64, macro models
 		return err
 	}
 
-	fmt.Sprintf("\nUpdated position to %d for macro %d", macro.Position, macro.ID)
-
 	return nil
 }
 
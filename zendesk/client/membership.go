@@ -63,19 +63,26 @@ type OrganizationMembershipAPI interface {
 // GetGroupMemberships fetches all group memberships
 // ref: https://developer.zendesk.com/api-reference/ticketing/users/group_memberships/#list-group-memberships
 func (z *Client) GetGroupMemberships(ctx context.Context) ([]GroupMembership, error) {
-	var result GroupMembershipListResponse
+	var memberships []GroupMembership
 
-	body, err := z.Get(ctx, "/group_memberships.json")
-	if err != nil {
-		return nil, err
-	}
+	for page := 1; ; page++ {
+		var result GroupMembershipListResponse
 
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return nil, err
-	}
+		body, err := z.Get(ctx, fmt.Sprintf("/group_memberships.json?page=%d&per_page=100", page))
+		if err != nil {
+			return nil, err
+		}
 
-	return result.GroupMemberships, nil
+		err = json.Unmarshal(body, &result)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, result.GroupMemberships...)
+
+		if result.NextPage == "" || len(result.GroupMemberships) == 0 {
+			return memberships, nil
+		}
+	}
 }
 
 // GetGroupMembership returns a specific group membership
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetGroupMemberships(t *testing.T) {
+	z := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/group_memberships.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"group_memberships":[{"id":1,"user_id":10,"group_id":100}],"next_page":"https://example.zendesk.com/api/v2/group_memberships.json?page=2"}`))
+		default:
+			w.Write([]byte(`{"group_memberships":[{"id":2,"user_id":11,"group_id":100}],"next_page":null}`))
+		}
+	})
+
+	memberships, err := z.GetGroupMemberships(context.Background())
+	if err != nil {
+		t.Fatalf("GetGroupMemberships returned an error: %v", err)
+	}
+	if len(memberships) != 2 || memberships[1].UserID != 11 {
+		t.Fatalf("GetGroupMemberships returned %v. should have returned both pages", memberships)
+	}
+}
@@ -1,20 +1,31 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
 )
 
 // OAuthClient represents a Zendesk OAuth client
 type OAuthClient struct {
-	ID          int64  `json:"id,omitempty"`
-	URL         string `json:"url,omitempty"`
-	Name        string `json:"name"`
-	Identifier  string `json:"identifier,omitempty"`
-	Secret      string `json:"secret,omitempty"`
-	RedirectURI string `json:"redirect_uri,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
-	UpdatedAt   string `json:"updated_at,omitempty"`
+	ID          int64    `json:"id,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Name        string   `json:"name"`
+	Identifier  string   `json:"identifier,omitempty"`
+	Kind        string   `json:"kind,omitempty"`
+	Company     string   `json:"company,omitempty"`
+	Description string   `json:"description,omitempty"`
+	LogoURL     string   `json:"logo_url,omitempty"`
+	UserID      int64    `json:"user_id,omitempty"`
+	Secret      string   `json:"secret,omitempty"`
+	RedirectURI []string `json:"redirect_uri,omitempty"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
 }
 
 // OAuthClientListResponse represents the response from listing OAuth clients
@@ -25,6 +36,10 @@ type OAuthClientListResponse struct {
 // OAuthClientAPI interface for OAuth client operations
 type OAuthClientAPI interface {
 	GetOAuthClients(ctx context.Context) ([]OAuthClient, error)
+	GetOAuthClient(ctx context.Context, id int64) (OAuthClient, error)
+	CreateOAuthClient(ctx context.Context, oauthClient OAuthClient) (OAuthClient, error)
+	UpdateOAuthClient(ctx context.Context, id int64, oauthClient OAuthClient) (OAuthClient, error)
+	DeleteOAuthClient(ctx context.Context, id int64) error
 }
 
 // GetOAuthClients fetches all OAuth clients
@@ -45,3 +60,209 @@ func (z *Client) GetOAuthClients(ctx context.Context) ([]OAuthClient, error) {
 	return result.Clients, nil
 }
 
+// GetOAuthClient returns a specific OAuth client
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_clients/#show-client
+func (z *Client) GetOAuthClient(ctx context.Context, id int64) (OAuthClient, error) {
+	var result struct {
+		Client OAuthClient `json:"client"`
+	}
+
+	body, err := z.Get(ctx, fmt.Sprintf("/oauth/clients/%d.json", id))
+	if err != nil {
+		return OAuthClient{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return OAuthClient{}, err
+	}
+
+	return result.Client, nil
+}
+
+// CreateOAuthClient creates a new OAuth client. The secret of the client is only returned by this call.
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_clients/#create-client
+func (z *Client) CreateOAuthClient(ctx context.Context, oauthClient OAuthClient) (OAuthClient, error) {
+	var data, result struct {
+		Client OAuthClient `json:"client"`
+	}
+	data.Client = oauthClient
+
+	body, err := z.Post(ctx, "/oauth/clients.json", data)
+	if err != nil {
+		return OAuthClient{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return OAuthClient{}, err
+	}
+
+	return result.Client, nil
+}
+
+// UpdateOAuthClient updates an OAuth client
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_clients/#update-client
+func (z *Client) UpdateOAuthClient(ctx context.Context, id int64, oauthClient OAuthClient) (OAuthClient, error) {
+	var data, result struct {
+		Client OAuthClient `json:"client"`
+	}
+	data.Client = oauthClient
+
+	body, err := z.Put(ctx, fmt.Sprintf("/oauth/clients/%d.json", id), data)
+	if err != nil {
+		return OAuthClient{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return OAuthClient{}, err
+	}
+
+	return result.Client, nil
+}
+
+// DeleteOAuthClient deletes an OAuth client
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_clients/#delete-client
+func (z *Client) DeleteOAuthClient(ctx context.Context, id int64) error {
+	err := z.Delete(ctx, fmt.Sprintf("/oauth/clients/%d.json", id))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// OAuthToken represents an OAuth access token issued for an OAuthClient
+type OAuthToken struct {
+	ID           int64    `json:"id,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	ClientID     int64    `json:"client_id"`
+	UserID       int64    `json:"user_id,omitempty"`
+	Token        string   `json:"token,omitempty"`
+	FullToken    string   `json:"full_token,omitempty"`
+	RefreshToken string   `json:"refresh_token,omitempty"`
+	Scopes       []string `json:"scopes"`
+	ExpiresAt    string   `json:"expires_at,omitempty"`
+	CreatedAt    string   `json:"created_at,omitempty"`
+	UsedAt       string   `json:"used_at,omitempty"`
+}
+
+// OAuthTokenAPI interface for OAuth token operations
+type OAuthTokenAPI interface {
+	GetOAuthToken(ctx context.Context, id int64) (OAuthToken, error)
+	CreateOAuthToken(ctx context.Context, token OAuthToken) (OAuthToken, error)
+	RevokeOAuthToken(ctx context.Context, id int64) error
+}
+
+// GetOAuthToken returns a specific OAuth token. The full token is never returned.
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_tokens/#show-token
+func (z *Client) GetOAuthToken(ctx context.Context, id int64) (OAuthToken, error) {
+	var result struct {
+		Token OAuthToken `json:"token"`
+	}
+
+	body, err := z.Get(ctx, fmt.Sprintf("/oauth/tokens/%d.json", id))
+	if err != nil {
+		return OAuthToken{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return OAuthToken{}, err
+	}
+
+	return result.Token, nil
+}
+
+// CreateOAuthToken issues a new OAuth token for the client given by token.ClientID.
+// The full token is only returned by this call.
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_tokens/#create-token
+func (z *Client) CreateOAuthToken(ctx context.Context, token OAuthToken) (OAuthToken, error) {
+	var data, result struct {
+		Token OAuthToken `json:"token"`
+	}
+	data.Token = token
+
+	body, err := z.Post(ctx, "/oauth/tokens.json", data)
+	if err != nil {
+		return OAuthToken{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return OAuthToken{}, err
+	}
+
+	return result.Token, nil
+}
+
+// RevokeOAuthToken revokes an OAuth token
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_tokens/#revoke-token
+func (z *Client) RevokeOAuthToken(ctx context.Context, id int64) error {
+	err := z.Delete(ctx, fmt.Sprintf("/oauth/tokens/%d.json", id))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// OAuthAccessToken represents the response of the OAuth token endpoint
+type OAuthAccessToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+}
+
+// RequestClientCredentialsToken exchanges the credentials of an OAuth client for an access token.
+// tokenURL is the full URL of the token endpoint, e.g. https://example.zendesk.com/oauth/tokens
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/grant_type_tokens/#client-credentials-grant-type
+func RequestClientCredentialsToken(ctx context.Context, httpClient *http.Client, tokenURL, clientID, clientSecret string) (OAuthAccessToken, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"grant_type":    "client_credentials",
+		"client_id":     clientID,
+		"client_secret": clientSecret,
+		"scope":         "read write",
+	})
+	if err != nil {
+		return OAuthAccessToken{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewReader(payload))
+	if err != nil {
+		return OAuthAccessToken{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return OAuthAccessToken{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return OAuthAccessToken{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return OAuthAccessToken{}, zendesk.NewError(body, resp)
+	}
+
+	var result OAuthAccessToken
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return OAuthAccessToken{}, err
+	}
+
+	if result.AccessToken == "" {
+		return OAuthAccessToken{}, fmt.Errorf("token endpoint %s did not return an access token", tokenURL)
+	}
+
+	return result, nil
+}
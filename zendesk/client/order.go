@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Position is the ID and position of an object in an ordered collection
+type Position struct {
+	ID       int64 `json:"id"`
+	Position int64 `json:"position"`
+}
+
+// OrderedCollection describes a collection whose objects are ordered by position
+type OrderedCollection struct {
+	// Name is the key of the objects in list responses, e.g. "triggers"
+	Name string
+	// Path is the path of the collection, without the .json suffix
+	Path string
+	// ReorderKey is set for collections with a reorder endpoint and is the
+	// key of the ID list it takes. Other collections are reordered with
+	// update_many.
+	ReorderKey string
+}
+
+var (
+	// ref: https://developer.zendesk.com/api-reference/ticketing/business-rules/triggers/#reorder-triggers
+	TriggerCollection = OrderedCollection{Name: "triggers", Path: "/triggers", ReorderKey: "trigger_ids"}
+	// ref: https://developer.zendesk.com/api-reference/ticketing/business-rules/automations/#update-many-automations
+	AutomationCollection = OrderedCollection{Name: "automations", Path: "/automations"}
+	// ref: https://developer.zendesk.com/api-reference/ticketing/business-rules/macros/#update-many-macros
+	MacroCollection = OrderedCollection{Name: "macros", Path: "/macros"}
+	// ref: https://developer.zendesk.com/api-reference/ticketing/business-rules/views/#update-many-views
+	ViewCollection = OrderedCollection{Name: "views", Path: "/views"}
+	// ref: https://developer.zendesk.com/api-reference/ticketing/business-rules/sla_policies/#reorder-sla-policies
+	SLAPolicyCollection = OrderedCollection{Name: "sla_policies", Path: "/slas/policies", ReorderKey: "sla_policy_ids"}
+	// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#reorder-ticket-forms
+	TicketFormCollection = OrderedCollection{Name: "ticket_forms", Path: "/ticket_forms", ReorderKey: "ticket_form_ids"}
+)
+
+// OrderAPI lists and changes the order of the objects of a collection
+type OrderAPI interface {
+	GetPositions(ctx context.Context, collection OrderedCollection) ([]Position, error)
+	Reorder(ctx context.Context, collection OrderedCollection, ids []int64) error
+}
+
+// GetPositions fetches the positions of every object of the collection
+func (z *Client) GetPositions(ctx context.Context, collection OrderedCollection) ([]Position, error) {
+	var positions []Position
+
+	for page := 1; ; page++ {
+		body, err := z.Get(ctx, fmt.Sprintf("%s.json?page=%d&per_page=100", collection.Path, page))
+		if err != nil {
+			return nil, err
+		}
+
+		var data map[string]json.RawMessage
+		err = json.Unmarshal(body, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		var pagePositions []Position
+		err = json.Unmarshal(data[collection.Name], &pagePositions)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s: %v", collection.Name, err)
+		}
+		positions = append(positions, pagePositions...)
+
+		var nextPage *string
+		if v, ok := data["next_page"]; ok {
+			err = json.Unmarshal(v, &nextPage)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if nextPage == nil || *nextPage == "" || len(pagePositions) == 0 {
+			return positions, nil
+		}
+	}
+}
+
+// Reorder moves the objects with the given IDs into the order of the list
+func (z *Client) Reorder(ctx context.Context, collection OrderedCollection, ids []int64) error {
+	if collection.ReorderKey != "" {
+		data := map[string][]int64{collection.ReorderKey: ids}
+		_, err := z.Put(ctx, collection.Path+"/reorder.json", data)
+		return err
+	}
+
+	positions := make([]Position, len(ids))
+	for i, id := range ids {
+		positions[i] = Position{ID: id, Position: int64(i + 1)}
+	}
+
+	data := map[string][]Position{collection.Name: positions}
+	_, err := z.Put(ctx, collection.Path+"/update_many.json", data)
+	return err
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	zd, err := zendesk.NewClient(server.Client())
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+	if err := zd.SetEndpointURL(server.URL); err != nil {
+		t.Fatalf("could not set endpoint: %v", err)
+	}
+	return &Client{Client: *zd}
+}
+
+func TestGetPositions(t *testing.T) {
+	z := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/triggers.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"triggers":[{"id":1,"position":2,"title":"a"}],"next_page":"https://example.zendesk.com/api/v2/triggers.json?page=2"}`))
+		default:
+			w.Write([]byte(`{"triggers":[{"id":2,"position":1,"title":"b"}],"next_page":null}`))
+		}
+	})
+
+	positions, err := z.GetPositions(context.Background(), TriggerCollection)
+	if err != nil {
+		t.Fatalf("GetPositions returned an error: %v", err)
+	}
+	expected := []Position{{ID: 1, Position: 2}, {ID: 2, Position: 1}}
+	if !reflect.DeepEqual(positions, expected) {
+		t.Fatalf("GetPositions returned %v. should have been %v", positions, expected)
+	}
+}
+
+func TestReorder(t *testing.T) {
+	cases := []struct {
+		collection OrderedCollection
+		path       string
+		body       string
+	}{
+		{TriggerCollection, "/triggers/reorder.json", `{"trigger_ids":[3,1,2]}`},
+		{TicketFormCollection, "/ticket_forms/reorder.json", `{"ticket_form_ids":[3,1,2]}`},
+		{MacroCollection, "/macros/update_many.json", `{"macros":[{"id":3,"position":1},{"id":1,"position":2},{"id":2,"position":3}]}`},
+	}
+
+	for _, c := range cases {
+		var path, body string
+		z := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			path, body = r.URL.Path, string(b)
+			w.Write([]byte(`{}`))
+		})
+
+		if err := z.Reorder(context.Background(), c.collection, []int64{3, 1, 2}); err != nil {
+			t.Fatalf("Reorder of %s returned an error: %v", c.collection.Name, err)
+		}
+		if path != c.path || body != c.body {
+			t.Fatalf("Reorder of %s sent %s to %s. should have sent %s to %s", c.collection.Name, body, path, c.body, c.path)
+		}
+	}
+}
@@ -4,17 +4,156 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
-// Queue represents a Zendesk queue
+// Queue represents a Zendesk omnichannel routing queue
 type Queue struct {
-	ID          int64                  `json:"id,omitempty"`
-	URL         string                 `json:"url,omitempty"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description,omitempty"`
-	Definition  map[string]interface{} `json:"definition,omitempty"`
-	CreatedAt   string                 `json:"created_at,omitempty"`
-	UpdatedAt   string                 `json:"updated_at,omitempty"`
+	ID              int64           `json:"id,omitempty"`
+	URL             string          `json:"url,omitempty"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description,omitempty"`
+	Definition      QueueDefinition `json:"definition"`
+	Priority        int64           `json:"priority,omitempty"`
+	PrimaryGroups   []int64         `json:"-"`
+	SecondaryGroups []int64         `json:"-"`
+	CreatedAt       string          `json:"created_at,omitempty"`
+	UpdatedAt       string          `json:"updated_at,omitempty"`
+}
+
+// QueueDefinition holds the conditions a ticket must meet to be routed to a queue
+type QueueDefinition struct {
+	All []QueueCondition `json:"all"`
+	Any []QueueCondition `json:"any"`
+}
+
+// QueueCondition is a condition of a queue definition
+type QueueCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// queueGroups is the shape in which queues return their groups
+type queueGroups struct {
+	Count  int64 `json:"count,omitempty"`
+	Groups []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name,omitempty"`
+	} `json:"groups"`
+}
+
+func (g *queueGroups) ids() []int64 {
+	if g == nil {
+		return nil
+	}
+
+	ids := make([]int64, len(g.Groups))
+	for i, group := range g.Groups {
+		ids[i] = group.ID
+	}
+	return ids
+}
+
+// MarshalJSON writes the groups of the queue as primary_groups_id and
+// secondary_groups_id, which is how they are set on create and update
+func (q Queue) MarshalJSON() ([]byte, error) {
+	type queue Queue
+
+	primary := q.PrimaryGroups
+	if primary == nil {
+		primary = []int64{}
+	}
+	secondary := q.SecondaryGroups
+	if secondary == nil {
+		secondary = []int64{}
+	}
+
+	return json.Marshal(struct {
+		queue
+		PrimaryGroupsID   []int64 `json:"primary_groups_id"`
+		SecondaryGroupsID []int64 `json:"secondary_groups_id"`
+	}{queue(q), primary, secondary})
+}
+
+// UnmarshalJSON reads the groups of the queue from the primary_groups and
+// secondary_groups objects returned by the API, or from the ID lists
+func (q *Queue) UnmarshalJSON(data []byte) error {
+	type queue Queue
+
+	var v struct {
+		queue
+		PrimaryGroups     *queueGroups `json:"primary_groups"`
+		SecondaryGroups   *queueGroups `json:"secondary_groups"`
+		PrimaryGroupsID   []int64      `json:"primary_groups_id"`
+		SecondaryGroupsID []int64      `json:"secondary_groups_id"`
+	}
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	*q = Queue(v.queue)
+	q.PrimaryGroups = v.PrimaryGroupsID
+	if v.PrimaryGroups != nil {
+		q.PrimaryGroups = v.PrimaryGroups.ids()
+	}
+	q.SecondaryGroups = v.SecondaryGroupsID
+	if v.SecondaryGroups != nil {
+		q.SecondaryGroups = v.SecondaryGroups.ids()
+	}
+
+	return nil
+}
+
+// MarshalJSON writes an empty list for missing conditions, as the API
+// requires both all and any
+func (d QueueDefinition) MarshalJSON() ([]byte, error) {
+	type definition QueueDefinition
+
+	v := definition(d)
+	if v.All == nil {
+		v.All = []QueueCondition{}
+	}
+	if v.Any == nil {
+		v.Any = []QueueCondition{}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON accepts numeric and boolean condition values, which are
+// returned as JSON scalars for fields such as group_id
+func (c *QueueCondition) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Field    string      `json:"field"`
+		Operator string      `json:"operator"`
+		Value    interface{} `json:"value"`
+	}
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	c.Field = v.Field
+	c.Operator = v.Operator
+	switch value := v.Value.(type) {
+	case nil:
+		c.Value = ""
+	case string:
+		c.Value = value
+	case float64:
+		c.Value = strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		c.Value = strconv.FormatBool(value)
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		c.Value = string(b)
+	}
+
+	return nil
 }
 
 // QueueListResponse represents the response from listing queues
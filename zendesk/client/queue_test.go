@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestQueueMarshalJSON(t *testing.T) {
+	queue := Queue{
+		Name: "VIP",
+		Definition: QueueDefinition{
+			All: []QueueCondition{{Field: "priority", Operator: "is", Value: "urgent"}},
+		},
+		Priority:      2,
+		PrimaryGroups: []int64{10, 11},
+	}
+
+	b, err := json.Marshal(queue)
+	if err != nil {
+		t.Fatalf("could not marshal queue: %v", err)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatalf("could not parse marshalled queue: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"name": "VIP",
+		"definition": map[string]interface{}{
+			"all": []interface{}{map[string]interface{}{"field": "priority", "operator": "is", "value": "urgent"}},
+			"any": []interface{}{},
+		},
+		"priority":            float64(2),
+		"primary_groups_id":   []interface{}{float64(10), float64(11)},
+		"secondary_groups_id": []interface{}{},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Fatalf("queue was marshalled as %v. should have been %v", body, expected)
+	}
+}
+
+func TestQueueUnmarshalJSON(t *testing.T) {
+	body := `{
+		"id": 1,
+		"name": "VIP",
+		"priority": 2,
+		"definition": {
+			"all": [{"field": "group_id", "operator": "is", "value": 10}],
+			"any": [{"field": "priority", "operator": "is", "value": "urgent"}]
+		},
+		"primary_groups": {"count": 2, "groups": [{"id": 10, "name": "Tier 1"}, {"id": 11, "name": "Tier 2"}]},
+		"secondary_groups": {"count": 0, "groups": []}
+	}`
+
+	var queue Queue
+	if err := json.Unmarshal([]byte(body), &queue); err != nil {
+		t.Fatalf("could not unmarshal queue: %v", err)
+	}
+
+	expected := Queue{
+		ID:       1,
+		Name:     "VIP",
+		Priority: 2,
+		Definition: QueueDefinition{
+			All: []QueueCondition{{Field: "group_id", Operator: "is", Value: "10"}},
+			Any: []QueueCondition{{Field: "priority", Operator: "is", Value: "urgent"}},
+		},
+		PrimaryGroups:   []int64{10, 11},
+		SecondaryGroups: []int64{},
+	}
+	if !reflect.DeepEqual(queue, expected) {
+		t.Fatalf("queue was unmarshalled as %+v. should have been %+v", queue, expected)
+	}
+}
+
+func TestQueueRoundTrip(t *testing.T) {
+	queue := Queue{
+		Name: "VIP",
+		Definition: QueueDefinition{
+			All: []QueueCondition{{Field: "priority", Operator: "is", Value: "urgent"}},
+			Any: []QueueCondition{{Field: "group_id", Operator: "is", Value: "10"}},
+		},
+		Priority:        2,
+		PrimaryGroups:   []int64{10},
+		SecondaryGroups: []int64{11},
+	}
+
+	b, err := json.Marshal(queue)
+	if err != nil {
+		t.Fatalf("could not marshal queue: %v", err)
+	}
+
+	var result Queue
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatalf("could not unmarshal queue: %v", err)
+	}
+	if !reflect.DeepEqual(result, queue) {
+		t.Fatalf("queue did not survive a round trip: %+v. should have been %+v", result, queue)
+	}
+}
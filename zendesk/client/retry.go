@@ -0,0 +1,159 @@
+package client
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a request is retried by default
+	DefaultMaxRetries = 3
+	// DefaultRetryMaxWait is the default upper bound of the wait between two attempts
+	DefaultRetryMaxWait = 60 * time.Second
+
+	defaultRetryMinWait = 1 * time.Second
+)
+
+// RetryTransport is an http.RoundTripper which retries requests rejected by
+// the Zendesk rate limiter (429) or failed with a server error (5xx).
+// Server errors are only retried for idempotent methods, since Zendesk may
+// have created the object before failing a POST. POST and PATCH are retried
+// on 429 and on 503 with Retry-After, which Zendesk sends before processing.
+// Requests wait for the duration given by the Retry-After header, or back off
+// exponentially with jitter. If Retry-After exceeds MaxWait, the response is
+// returned instead of retrying early into another 429.
+// ref: https://developer.zendesk.com/api-reference/introduction/rate-limits/
+type RetryTransport struct {
+	Base       http.RoundTripper
+	MaxRetries int
+	MaxWait    time.Duration
+
+	minWait time.Duration
+	// jitter returns a random duration in [0, n)
+	jitter func(n int64) int64
+}
+
+// NewRetryTransport wraps base with retries. If base is nil, http.DefaultTransport is used.
+func NewRetryTransport(base http.RoundTripper, maxRetries int, maxWait time.Duration) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &RetryTransport{
+		Base:       base,
+		MaxRetries: maxRetries,
+		MaxWait:    maxWait,
+		minWait:    defaultRetryMinWait,
+		jitter:     rand.Int63n,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.Base.RoundTrip(r)
+		if err != nil || attempt >= t.MaxRetries || !shouldRetry(req, resp) {
+			return resp, err
+		}
+
+		wait, ok := t.backoff(attempt, resp)
+		if !ok {
+			return resp, nil
+		}
+
+		// A request body can only be sent again if it can be recreated
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode < http.StatusInternalServerError || resp.StatusCode == http.StatusNotImplemented {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		_, ok := retryAfter(resp)
+		return resp.StatusCode == http.StatusServiceUnavailable && ok
+	default:
+		return true
+	}
+}
+
+// backoff returns the duration to wait before the next attempt, or false if
+// Retry-After asks for a longer wait than MaxWait
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if wait, ok := retryAfter(resp); ok {
+		if t.MaxWait > 0 && wait > t.MaxWait {
+			return 0, false
+		}
+		return wait, true
+	}
+
+	wait := time.Duration(float64(t.minWait) * math.Pow(2, float64(attempt)))
+	if t.MaxWait > 0 && wait > t.MaxWait {
+		wait = t.MaxWait
+	}
+
+	// wait between half and all of the backoff, so that clients rate limited
+	// together don't retry together
+	if half := int64(wait / 2); half > 0 && t.jitter != nil {
+		wait = time.Duration(half + t.jitter(half))
+	}
+
+	return wait, true
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(v); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryClient(maxRetries int, maxWait time.Duration) *http.Client {
+	transport := NewRetryTransport(nil, maxRetries, maxWait)
+	transport.minWait = time.Millisecond
+	return &http.Client{Transport: transport}
+}
+
+func TestRetryTransportRetriesRateLimitedRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"trigger":{}}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestRetryClient(3, time.Second)
+	resp, err := c.Post(server.URL, "application/json", strings.NewReader(`{"trigger":{}}`))
+	if err != nil {
+		t.Fatalf("request returned an error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status was %d. should have been %d", resp.StatusCode, http.StatusOK)
+	}
+	if v := atomic.LoadInt32(&calls); v != 3 {
+		t.Fatalf("server was called %d times. should have been 3", v)
+	}
+}
+
+func TestRetryTransportGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := newTestRetryClient(2, time.Second)
+	resp, err := c.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request returned an error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("status was %d. should have been %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if v := atomic.LoadInt32(&calls); v != 3 {
+		t.Fatalf("server was called %d times. should have been 3", v)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	c := newTestRetryClient(3, time.Second)
+	resp, err := c.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request returned an error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if v := atomic.LoadInt32(&calls); v != 1 {
+		t.Fatalf("server was called %d times. should have been 1", v)
+	}
+}
+
+func TestRetryTransportStopsWhenContextIsCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("could not create request: %v", err)
+	}
+
+	c := newTestRetryClient(3, time.Minute)
+	start := time.Now()
+	_, err = c.Do(req)
+	if err == nil {
+		t.Fatal("request did not return an error after the context was canceled")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("request waited %s after the context was canceled", elapsed)
+	}
+}
+
+func TestRetryTransportDoesNotRetryPostOnServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := newTestRetryClient(3, time.Second)
+	resp, err := c.Post(server.URL, "application/json", strings.NewReader(`{"trigger":{}}`))
+	if err != nil {
+		t.Fatalf("request returned an error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if v := atomic.LoadInt32(&calls); v != 1 {
+		t.Fatalf("server was called %d times. should have been 1, since the trigger may have been created", v)
+	}
+}
+
+func TestRetryTransportRetriesPostOnServiceUnavailableWithRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := newTestRetryClient(3, time.Second)
+	resp, err := c.Post(server.URL, "application/json", strings.NewReader(`{"trigger":{}}`))
+	if err != nil {
+		t.Fatalf("request returned an error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status was %d. should have been %d", resp.StatusCode, http.StatusCreated)
+	}
+	if v := atomic.LoadInt32(&calls); v != 2 {
+		t.Fatalf("server was called %d times. should have been 2", v)
+	}
+}
+
+func TestRetryTransportReturnsRetryAfterLongerThanMaxWait(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newTestRetryClient(3, time.Minute)
+	resp, err := c.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request returned an error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status was %d. should have been %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if v := atomic.LoadInt32(&calls); v != 1 {
+		t.Fatalf("server was called %d times. should have been 1", v)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := NewRetryTransport(nil, 5, 10*time.Second)
+	transport.jitter = func(n int64) int64 { return n - 1 }
+
+	resp := &http.Response{Header: http.Header{}}
+	if v, ok := transport.backoff(2, resp); !ok || v != 4*time.Second-time.Nanosecond {
+		t.Fatalf("backoff was %s. should have been just under 4s", v)
+	}
+	if v, ok := transport.backoff(5, resp); !ok || v != 10*time.Second-time.Nanosecond {
+		t.Fatalf("backoff was %s. should have been capped at 10s", v)
+	}
+
+	transport.jitter = func(n int64) int64 { return 0 }
+	if v, _ := transport.backoff(2, resp); v != 2*time.Second {
+		t.Fatalf("backoff was %s. should have been at least half of 4s", v)
+	}
+
+	resp.Header.Set("Retry-After", "7")
+	if v, ok := transport.backoff(0, resp); !ok || v != 7*time.Second {
+		t.Fatalf("backoff was %s. should have honoured Retry-After of 7s", v)
+	}
+
+	resp.Header.Set("Retry-After", "120")
+	if _, ok := transport.backoff(0, resp); ok {
+		t.Fatal("backoff retried a Retry-After of 120s. should have given up since it exceeds 10s")
+	}
+}
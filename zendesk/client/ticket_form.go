@@ -17,6 +17,7 @@ type TicketFormAPI interface {
 	DeleteTicketForm(ctx context.Context, id int64) error
 	UpdateTicketForm(ctx context.Context, id int64, form models.TicketForm) (models.TicketForm, error)
 	GetTicketForm(ctx context.Context, id int64) (models.TicketForm, error)
+	CloneTicketForm(ctx context.Context, id int64) (models.TicketForm, error)
 }
 
 // GetTicketForms fetches ticket forms
@@ -109,6 +110,25 @@ func (z *Client) UpdateTicketForm(ctx context.Context, id int64, form models.Tic
 	return result.TicketForm, nil
 }
 
+// CloneTicketForm creates a copy of the specified ticket form and returns the copy
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#clone-an-already-existing-ticket-form
+func (z *Client) CloneTicketForm(ctx context.Context, id int64) (models.TicketForm, error) {
+	var result struct {
+		TicketForm models.TicketForm `json:"ticket_form"`
+	}
+
+	body, err := z.Post(ctx, fmt.Sprintf("/ticket_forms/%d/clone.json", id), struct{}{})
+	if err != nil {
+		return models.TicketForm{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return models.TicketForm{}, err
+	}
+	return result.TicketForm, nil
+}
+
 // DeleteTicketForm deletes the specified ticket form
 // ref: https://developer.zendesk.com/rest_api/docs/support/ticket_forms#delete-ticket-form
 func (z *Client) DeleteTicketForm(ctx context.Context, id int64) error {
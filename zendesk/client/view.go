@@ -76,8 +76,6 @@ func (z *Client) GetView(ctx context.Context, id int64) (models.View, error) {
 	}
 
 	body, err := z.Get(ctx, fmt.Sprintf("/views/%d.json", id))
-	fmt.Println("GET bar")
-	fmt.Println(string(body))
 
 	if err != nil {
 		return models.View{}, err
@@ -103,10 +101,6 @@ func (z *Client) UpdateView(ctx context.Context, id int64, view models.View) (mo
 
 	data.View = mapViewToViewCreateOrUpdate(view)
 
-	jsonData, err := json.Marshal(data)
-	fmt.Println("Update Processed payload: JSON")
-	fmt.Println(string(jsonData))
-
 	z.UpdateViewPosition(ctx, id, models.ViewPosition{
 		ID:       id,
 		Position: view.Position,
@@ -115,8 +109,6 @@ func (z *Client) UpdateView(ctx context.Context, id int64, view models.View) (mo
 	body, err := z.Put(ctx, fmt.Sprintf("/views/%d.json", id), data)
 
 	if err != nil {
-		fmt.Println("Printing Error")
-		fmt.Printf("%+v\n", err)
 		return models.View{}, err
 	}
 
@@ -145,8 +137,6 @@ func (z *Client) UpdateViewPosition(ctx context.Context, id int64, view models.V
 		return err
 	}
 
-	fmt.Sprintf("\nUpdated position to %d for view %d", view.Position, view.ID)
-
 	return nil
 }
 
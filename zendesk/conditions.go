@@ -0,0 +1,327 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources which use condition blocks. Not every condition field is
+// available in every one of them.
+const (
+	conditionTrigger    = "trigger"
+	conditionAutomation = "automation"
+	conditionView       = "view"
+	conditionSLAPolicy  = "sla_policy"
+	conditionQueue      = "queue"
+)
+
+type conditionValueType int
+
+const (
+	// conditionValueString accepts any value
+	conditionValueString conditionValueType = iota
+	// conditionValueID accepts a numeric ID or a placeholder such as current_user
+	conditionValueID
+	// conditionValueHours accepts a number of hours
+	conditionValueHours
+	// conditionValueEnum accepts one of conditionField.values
+	conditionValueEnum
+)
+
+// conditionField describes a field of the condition catalogue
+type conditionField struct {
+	operators []string
+	valueType conditionValueType
+	values    []string
+	// resources lists where the field can be used; nil means everywhere
+	resources []string
+}
+
+var (
+	changeOperators = []string{"is", "is_not", "changed", "value", "value_previous", "not_changed", "not_value", "not_value_previous"}
+	orderOperators  = []string{"is", "is_not", "less_than", "greater_than", "changed", "value", "value_previous", "not_changed", "not_value", "not_value_previous"}
+	hourOperators   = []string{"is", "less_than", "greater_than", "is_business_hours", "less_than_business_hours", "greater_than_business_hours"}
+	countOperators  = []string{"is", "less_than", "greater_than"}
+	tagOperators    = []string{"includes", "not_includes"}
+	textOperators   = []string{"includes", "not_includes", "is", "is_not"}
+	isOperators     = []string{"is", "is_not"}
+
+	// customFieldOperators are only used by custom fields, e.g. present on a
+	// text field or within_previous_n_days on a date field
+	customFieldOperators = []string{"present", "not_present", "within_previous_n_days", "within_next_n_days"}
+
+	timeBasedResources = []string{conditionAutomation, conditionView}
+)
+
+// conditionCatalogue lists the documented condition fields of business rules and SLA policies.
+// ref: https://developer.zendesk.com/documentation/ticketing/reference-guides/conditions-reference/
+var conditionCatalogue = map[string]conditionField{
+	"status": {
+		operators: orderOperators,
+		valueType: conditionValueEnum,
+		values:    []string{"new", "open", "pending", "hold", "solved", "closed"},
+	},
+	"type": {
+		operators: changeOperators,
+		valueType: conditionValueEnum,
+		values:    []string{"", "question", "incident", "problem", "task"},
+	},
+	"priority": {
+		operators: orderOperators,
+		valueType: conditionValueEnum,
+		values:    []string{"", "low", "normal", "high", "urgent"},
+	},
+	"group_id":           {operators: changeOperators, valueType: conditionValueID},
+	"assignee_id":        {operators: changeOperators, valueType: conditionValueID},
+	"requester_id":       {operators: changeOperators, valueType: conditionValueID},
+	"organization_id":    {operators: changeOperators, valueType: conditionValueID},
+	"brand_id":           {operators: isOperators, valueType: conditionValueID},
+	"ticket_form_id":     {operators: changeOperators, valueType: conditionValueID},
+	"custom_status_id":   {operators: changeOperators, valueType: conditionValueID},
+	"locale_id":          {operators: isOperators, valueType: conditionValueID},
+	"recipient":          {operators: isOperators, valueType: conditionValueString},
+	"current_tags":       {operators: tagOperators, valueType: conditionValueString},
+	"via_id":             {operators: isOperators, valueType: conditionValueString},
+	"exact_created_at":   {operators: countOperators, valueType: conditionValueString},
+	"satisfaction_score": {operators: orderOperators, valueType: conditionValueString},
+	"sla_next_breach_at": {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"subject_includes_word": {
+		operators: textOperators,
+		valueType: conditionValueString,
+	},
+	"comment_includes_word": {
+		operators: textOperators,
+		valueType: conditionValueString,
+		resources: []string{conditionTrigger},
+	},
+	"current_via_id": {
+		operators: isOperators,
+		valueType: conditionValueString,
+		resources: []string{conditionTrigger},
+	},
+	"update_type": {
+		operators: []string{"is"},
+		valueType: conditionValueEnum,
+		values:    []string{"Create", "Change"},
+		resources: []string{conditionTrigger},
+	},
+	"role": {
+		operators: isOperators,
+		valueType: conditionValueEnum,
+		values:    []string{"end_user", "agent", "admin"},
+		resources: []string{conditionTrigger},
+	},
+	"comment_is_public": {
+		operators: []string{"is"},
+		valueType: conditionValueEnum,
+		values:    []string{"true", "false", "not_relevant", "requester_can_see_comment"},
+		resources: []string{conditionTrigger},
+	},
+	"ticket_is_public": {
+		operators: []string{"is"},
+		valueType: conditionValueEnum,
+		values:    []string{"public", "private"},
+		resources: []string{conditionTrigger, conditionSLAPolicy},
+	},
+	"reopens":        {operators: countOperators, valueType: conditionValueHours, resources: []string{conditionTrigger}},
+	"replies":        {operators: countOperators, valueType: conditionValueHours, resources: []string{conditionTrigger}},
+	"agent_stations": {operators: countOperators, valueType: conditionValueHours, resources: []string{conditionTrigger}},
+	"group_stations": {operators: countOperators, valueType: conditionValueHours, resources: []string{conditionTrigger}},
+	"in_business_hours": {
+		operators: []string{"is"},
+		valueType: conditionValueEnum,
+		values:    []string{"true", "false"},
+		resources: []string{conditionTrigger},
+	},
+	"NEW":                  {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"OPEN":                 {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"PENDING":              {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"HOLD":                 {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"SOLVED":               {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"CLOSED":               {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"assigned_at":          {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"updated_at":           {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"requester_updated_at": {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"assignee_updated_at":  {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"due_date":             {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+	"until_due_date":       {operators: hourOperators, valueType: conditionValueHours, resources: timeBasedResources},
+}
+
+// customFieldConditionPattern matches custom ticket, user and organization fields.
+// Their operators and values depend on the field type, so they are not checked.
+var customFieldConditionPattern = regexp.MustCompile(`^(custom_fields_\d+|(requester|organization)\.custom_fields\.[A-Za-z0-9_-]+)$`)
+
+var (
+	conditionIDPattern    = regexp.MustCompile(`^(\d+|[a-z_]+)$`)
+	conditionHoursPattern = regexp.MustCompile(`^\d+$`)
+)
+
+// conditionOperators returns every operator used by a field of the catalogue or by custom fields
+func conditionOperators() []string {
+	set := map[string]bool{}
+	for _, op := range customFieldOperators {
+		set[op] = true
+	}
+	for _, f := range conditionCatalogue {
+		for _, op := range f.operators {
+			set[op] = true
+		}
+	}
+
+	operators := make([]string, 0, len(set))
+	for op := range set {
+		operators = append(operators, op)
+	}
+	sort.Strings(operators)
+	return operators
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// validateConditionField checks the field of a condition of the given resource.
+// Fields missing from the catalogue only raise a warning, so that fields added
+// to Zendesk after this provider was released can still be used.
+func validateConditionField(resource string) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		field, ok := i.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Expected type of field to be string",
+				AttributePath: path,
+			}}
+		}
+
+		if customFieldConditionPattern.MatchString(field) {
+			return nil
+		}
+
+		f, ok := conditionCatalogue[field]
+		if !ok {
+			return diag.Diagnostics{{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("Unknown condition field %q", field),
+				Detail:        "The field is not in the condition catalogue of this provider and is sent to Zendesk unchecked. Custom fields must be written as custom_fields_<id>.",
+				AttributePath: path,
+			}}
+		}
+
+		if f.resources != nil && !containsString(f.resources, resource) {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Condition field %q is not available in %s conditions", field, resource),
+				Detail:        fmt.Sprintf("It can only be used in %s conditions.", strings.Join(f.resources, " and ")),
+				AttributePath: path,
+			}}
+		}
+
+		return nil
+	}
+}
+
+// validateConditionOperator warns about operators which no condition field of
+// the catalogue supports. Like unknown fields, they are still sent to Zendesk,
+// since operators of custom fields depend on the field type.
+func validateConditionOperator() schema.SchemaValidateDiagFunc {
+	operators := conditionOperators()
+
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		operator, ok := i.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Expected type of operator to be string",
+				AttributePath: path,
+			}}
+		}
+
+		if !containsString(operators, operator) {
+			return diag.Diagnostics{{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("Unknown condition operator %q", operator),
+				Detail:        fmt.Sprintf("The operator is sent to Zendesk unchecked. Known operators are %s.", strings.Join(operators, ", ")),
+				AttributePath: path,
+			}}
+		}
+
+		return nil
+	}
+}
+
+// validateCondition checks that operator and value fit the field of a condition
+func validateCondition(field, operator, value string) error {
+	f, ok := conditionCatalogue[field]
+	if !ok {
+		return nil
+	}
+
+	if operator != "" && !containsString(f.operators, operator) {
+		return fmt.Errorf("operator %q is not supported by condition field %q, allowed operators are %s", operator, field, strings.Join(f.operators, ", "))
+	}
+
+	// values depending on other resources are unknown during plan
+	if value == "" {
+		return nil
+	}
+
+	// changed and not_changed don't compare against a value
+	if operator == "changed" || operator == "not_changed" {
+		return nil
+	}
+
+	switch f.valueType {
+	case conditionValueID:
+		if !conditionIDPattern.MatchString(value) {
+			return fmt.Errorf("condition field %q expects a numeric ID, got %q", field, value)
+		}
+	case conditionValueHours:
+		if !conditionHoursPattern.MatchString(value) {
+			return fmt.Errorf("condition field %q expects a whole number, got %q", field, value)
+		}
+	case conditionValueEnum:
+		if !containsString(f.values, value) {
+			return fmt.Errorf("condition field %q expects one of %s, got %q", field, strings.Join(f.values, ", "), value)
+		}
+	}
+
+	return nil
+}
+
+// validateConditionsDiff checks the combination of field, operator and value of
+// every condition in the "all" and "any" blocks, which can't be done by
+// validating attributes one at a time.
+func validateConditionsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"all", "any"} {
+		v, ok := d.GetOk(key)
+		if !ok {
+			continue
+		}
+
+		for _, c := range v.(*schema.Set).List() {
+			condition := c.(map[string]interface{})
+			field := condition["field"].(string)
+			operator := condition["operator"].(string)
+			value := condition["value"].(string)
+
+			if err := validateCondition(field, operator, value); err != nil {
+				return fmt.Errorf("invalid condition in %s: %v", key, err)
+			}
+		}
+	}
+
+	return nil
+}
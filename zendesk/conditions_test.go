@@ -0,0 +1,115 @@
+package zendesk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidateConditionField(t *testing.T) {
+	path := cty.GetAttrPath("field")
+
+	cases := []struct {
+		resource string
+		field    string
+		severity diag.Severity
+		ok       bool
+	}{
+		{conditionTrigger, "status", 0, true},
+		{conditionTrigger, "custom_fields_360000000001", 0, true},
+		{conditionView, "requester.custom_fields.account_tier", 0, true},
+		{conditionAutomation, "SOLVED", 0, true},
+		{conditionTrigger, "SOLVED", diag.Error, false},
+		{conditionTrigger, "statuss", diag.Warning, false},
+	}
+
+	for _, c := range cases {
+		diags := validateConditionField(c.resource)(c.field, path)
+		if c.ok {
+			if len(diags) != 0 {
+				t.Fatalf("field %s in %s conditions returned diagnostics: %v", c.field, c.resource, diags)
+			}
+			continue
+		}
+
+		if len(diags) != 1 || diags[0].Severity != c.severity {
+			t.Fatalf("field %s in %s conditions returned %v. should have returned one diagnostic of severity %v", c.field, c.resource, diags, c.severity)
+		}
+	}
+}
+
+func TestValidateConditionOperator(t *testing.T) {
+	v := validateConditionOperator()
+	path := cty.GetAttrPath("operator")
+
+	if diags := v("greater_than_business_hours", path); len(diags) != 0 {
+		t.Fatalf("operator greater_than_business_hours returned diagnostics: %v", diags)
+	}
+
+	if diags := v("present", path); len(diags) != 0 {
+		t.Fatalf("operator present returned diagnostics: %v", diags)
+	}
+
+	if diags := v("is_nt", path); len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("operator is_nt returned %v. should have returned a warning", diags)
+	}
+}
+
+func TestTriggerCustomFieldPresentCondition(t *testing.T) {
+	r := resourceZendeskTrigger()
+	raw := map[string]interface{}{
+		"title": "Order number set",
+		"all": []interface{}{
+			map[string]interface{}{"field": "custom_fields_20", "operator": "present", "value": ""},
+			map[string]interface{}{"field": "custom_fields_21", "operator": "within_previous_n_days", "value": "7"},
+		},
+		"action": []interface{}{
+			map[string]interface{}{"field": "status", "value": "open"},
+		},
+	}
+
+	if diags := r.Validate(terraform.NewResourceConfigRaw(raw)); len(diags) != 0 {
+		t.Fatalf("trigger with a present condition on a custom field returned diagnostics: %v", diags)
+	}
+
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("trigger with a present condition on a custom field could not be planned: %v", err)
+	}
+}
+
+func TestValidateCondition(t *testing.T) {
+	cases := []struct {
+		field    string
+		operator string
+		value    string
+		ok       bool
+	}{
+		{"status", "less_than", "solved", true},
+		{"status", "is", "Solved", false},
+		{"role", "is", "end_user", true},
+		{"role", "less_than", "end_user", false},
+		{"update_type", "is", "Change", true},
+		{"group_id", "is", "360000000001", true},
+		{"group_id", "is", "current_groups", true},
+		{"group_id", "is", "Tier 2", false},
+		{"SOLVED", "greater_than", "96", true},
+		{"SOLVED", "greater_than", "4 days", false},
+		{"status", "changed", "", true},
+		{"priority", "is", "", true},
+		{"custom_fields_360000000001", "is_not", "anything", true},
+	}
+
+	for _, c := range cases {
+		err := validateCondition(c.field, c.operator, c.value)
+		if c.ok && err != nil {
+			t.Fatalf("condition %s %s %q returned an error: %v", c.field, c.operator, c.value, err)
+		}
+		if !c.ok && err == nil {
+			t.Fatalf("condition %s %s %q should have been rejected", c.field, c.operator, c.value)
+		}
+	}
+}
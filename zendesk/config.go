@@ -1,8 +1,135 @@
 package zendesk
 
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
 // Config is configuration struct for Zendesk credentials
 type Config struct {
-	Account string
-	Email   string
-	Token   string
+	Account      string
+	Email        string
+	Token        string
+	OAuthToken   string
+	ClientID     string
+	ClientSecret string
+	APIURL       string
+	ProxyURL     string
+	CABundleFile string
+	MaxRetries   int
+	RetryMaxWait time.Duration
+	StrictLiquid bool
+}
+
+// validate checks that exactly one authentication method is configured
+func (c Config) validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c.Account == "" && c.APIURL == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing Zendesk account",
+			Detail:   "account or api_url must be set in the provider block or via the " + accountVar + " or " + apiURLVar + " environment variable.",
+		})
+	}
+
+	methods := 0
+	if c.Email != "" || c.Token != "" {
+		methods++
+		if c.Email == "" || c.Token == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Incomplete API token credentials",
+				Detail:   "email and token must be set together.",
+			})
+		}
+	}
+	if c.OAuthToken != "" {
+		methods++
+	}
+	if c.ClientID != "" || c.ClientSecret != "" {
+		methods++
+		if c.ClientID == "" || c.ClientSecret == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Incomplete OAuth client credentials",
+				Detail:   "client_id and client_secret must be set together.",
+			})
+		}
+	}
+
+	switch {
+	case methods == 0:
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing Zendesk credentials",
+			Detail:   "One of email and token, oauth_token, or client_id and client_secret must be set.",
+		})
+	case methods > 1:
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Conflicting Zendesk credentials",
+			Detail:   "Only one of email and token, oauth_token, or client_id and client_secret can be set.",
+		})
+	}
+
+	return diags
+}
+
+// transport builds the HTTP transport for API requests from the proxy and CA bundle settings
+func (c Config) transport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse proxy_url %s: %v", c.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if c.CABundleFile != "" {
+		pem, err := os.ReadFile(c.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ca_bundle_file %s: %v", c.CABundleFile, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_bundle_file %s does not contain any PEM encoded certificate", c.CABundleFile)
+		}
+
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	return transport, nil
+}
+
+// oauthTokenURL returns the OAuth token endpoint of the account.
+// When api_url is set, the endpoint is resolved against its host.
+func (c Config) oauthTokenURL() string {
+	if c.APIURL == "" {
+		return fmt.Sprintf("https://%s.zendesk.com/oauth/tokens", c.Account)
+	}
+
+	u, err := url.Parse(c.APIURL)
+	if err != nil {
+		return c.APIURL
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api/v2") + "/oauth/tokens"
+	return u.String()
 }
@@ -0,0 +1,150 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// https://developer.zendesk.com/api-reference/ticketing/groups/groups/#show-group
+func dataSourceZendeskGroup() *schema.Resource {
+	groupSchema := groupDataSourceSchema()
+	groupSchema["id"] = &schema.Schema{
+		Description:  "The ID of the group.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+	groupSchema["name"] = &schema.Schema{
+		Description:  "The name of the group. It must match exactly one group which isn't deleted.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	return &schema.Resource{
+		Description: "Provides a data source to look up a group by ID or name, including groups managed outside of Terraform.",
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+			zd := i.(client.GroupAPI)
+			return readGroupDataSource(ctx, d, zd)
+		},
+
+		Schema: groupSchema,
+	}
+}
+
+// groupDataSourceSchema returns the computed attributes set by marshalGroupDataSource
+func groupDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description: "The name of the group.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"url": {
+			Description: "The API url of the group.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"description": {
+			Description: "The description of the group.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"default": {
+			Description: "Whether the group is the default group of the account.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"deleted": {
+			Description: "Whether the group has been deleted.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+}
+
+func marshalGroupDataSource(group client.Group, d identifiableGetterSetter) error {
+	fields := map[string]interface{}{
+		"name":        group.Name,
+		"url":         group.URL,
+		"description": group.Description,
+		"default":     group.Default,
+		"deleted":     group.Deleted,
+	}
+
+	return setSchemaFields(d, fields)
+}
+
+// getAllGroups fetches every page of groups. Deleted groups aren't listed.
+func getAllGroups(ctx context.Context, zd client.GroupAPI) ([]client.Group, error) {
+	var groups []client.Group
+
+	opts := client.GroupListOptions{}
+	opts.PerPage = 100
+	opts.Page = 1
+	for {
+		page, p, err := zd.GetGroups(ctx, &opts)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, page...)
+
+		if !p.HasNext() {
+			return groups, nil
+		}
+		opts.Page++
+	}
+}
+
+func readGroupDataSource(ctx context.Context, d identifiableGetterSetter, zd client.GroupAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var group client.Group
+
+	if v, ok := d.GetOk("id"); ok {
+		id, err := atoi64(v.(string))
+		if err != nil {
+			return diag.Errorf("could not parse group id %s: %v", v, err)
+		}
+
+		group, err = zd.GetGroup(ctx, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		groups, err := getAllGroups(ctx, zd)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		var matches []client.Group
+		for _, g := range groups {
+			if g.Name == name {
+				matches = append(matches, g)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("no group with name %q found", name)
+		case 1:
+			group = matches[0]
+		default:
+			return diag.Errorf("%d groups have the name %q, look the group up by id instead", len(matches), name)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d", group.ID))
+	err := marshalGroupDataSource(group, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
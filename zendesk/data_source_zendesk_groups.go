@@ -0,0 +1,161 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// groupsAPI lists groups, deleted groups and the memberships counted for each of them
+type groupsAPI interface {
+	client.GroupAPI
+	newClient.DeletedGroupAPI
+	GetGroupMemberships(ctx context.Context) ([]newClient.GroupMembership, error)
+}
+
+// https://developer.zendesk.com/api-reference/ticketing/groups/groups/#list-groups
+func dataSourceZendeskGroups() *schema.Resource {
+	groupSchema := groupDataSourceSchema()
+	groupSchema["id"] = &schema.Schema{
+		Description: "The ID of the group.",
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+	groupSchema["member_count"] = &schema.Schema{
+		Description: "The number of agents who are members of the group.",
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Provides a data source to list the groups of the account, including those managed outside of Terraform.",
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+			zd := i.(groupsAPI)
+			return readGroupsDataSource(ctx, d, zd)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"include_deleted": {
+				Description: "Also return deleted groups.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"name_regex": {
+				Description:  "Only return groups whose name matches this regular expression.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"ids": {
+				Description: "The IDs of the matching groups.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"groups": {
+				Description: "The matching groups.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: groupSchema,
+				},
+			},
+		},
+	}
+}
+
+// getGroupMemberCounts counts the memberships of every group
+func getGroupMemberCounts(ctx context.Context, zd groupsAPI) (map[int64]int, error) {
+	memberships, err := zd.GetGroupMemberships(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[int64]int{}
+	for _, m := range memberships {
+		counts[m.GroupID]++
+	}
+	return counts, nil
+}
+
+func readGroupsDataSource(ctx context.Context, d identifiableGetterSetter, zd groupsAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	includeDeleted := d.Get("include_deleted").(bool)
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		re, err := regexp.Compile(v.(string))
+		if err != nil {
+			return diag.Errorf("could not parse name_regex %s: %v", v, err)
+		}
+		nameRegex = re
+	}
+
+	groups, err := getAllGroups(ctx, zd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// deleted groups are only listed by their own endpoint
+	if includeDeleted {
+		deleted, err := zd.GetDeletedGroups(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		groups = append(groups, deleted...)
+	}
+
+	var matches []client.Group
+	for _, group := range groups {
+		if nameRegex != nil && !nameRegex.MatchString(group.Name) {
+			continue
+		}
+		matches = append(matches, group)
+	}
+
+	counts := map[int64]int{}
+	if len(matches) > 0 {
+		counts, err = getGroupMemberCounts(ctx, zd)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	ids := []string{}
+	groupList := []map[string]interface{}{}
+	for _, group := range matches {
+		m := &identifiableMapGetterSetter{mapGetterSetter: make(mapGetterSetter)}
+		err := marshalGroupDataSource(group, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		m.mapGetterSetter["id"] = int(group.ID)
+		m.mapGetterSetter["member_count"] = counts[group.ID]
+
+		ids = append(ids, fmt.Sprintf("%d", group.ID))
+		groupList = append(groupList, m.mapGetterSetter)
+	}
+
+	d.SetId("groups")
+	err = d.Set("ids", ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("groups", groupList)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,213 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nukosuke/go-zendesk/zendesk"
+	"github.com/nukosuke/go-zendesk/zendesk/mock"
+	"github.com/nukosuke/terraform-provider-zendesk/internal/fakezendesk"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+func expectGroupPages(c *mock.Client, pages ...[]zendesk.Group) {
+	next := "next"
+	for i, page := range pages {
+		p := zendesk.Page{}
+		if i < len(pages)-1 {
+			p.NextPage = &next
+		}
+
+		pageNumber := i + 1
+		c.EXPECT().GetGroups(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, opts *zendesk.GroupListOptions) ([]zendesk.Group, zendesk.Page, error) {
+				if opts.Page != pageNumber {
+					return nil, zendesk.Page{}, &zendesk.OptionsError{}
+				}
+				return page, p, nil
+			})
+	}
+}
+
+// groupsMockClient serves groups from the mock and memberships from a fixed list
+type groupsMockClient struct {
+	*mock.Client
+	memberships []newClient.GroupMembership
+}
+
+func (c groupsMockClient) GetGroupMemberships(ctx context.Context) ([]newClient.GroupMembership, error) {
+	return c.memberships, nil
+}
+
+func (c groupsMockClient) GetDeletedGroups(ctx context.Context) ([]zendesk.Group, error) {
+	return nil, fmt.Errorf("deleted groups should only be listed with include_deleted")
+}
+
+func TestReadGroupDataSourceByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock.NewClient(ctrl)
+	expectGroupPages(c,
+		[]zendesk.Group{{ID: 1, Name: "Workforce"}},
+		[]zendesk.Group{
+			{ID: 2, Name: "Support Tier 2"},
+			{ID: 3, Name: "Support", Description: "Tier 1"},
+		},
+	)
+
+	m := newIdentifiableGetterSetter()
+	m.Set("name", "Support")
+
+	if diags := readGroupDataSource(context.Background(), m, c); len(diags) != 0 {
+		t.Fatalf("readGroupDataSource returned an error: %v", diags)
+	}
+	if v := m.Id(); v != "3" {
+		t.Fatalf("group data source had id %s. should have been 3", v)
+	}
+	if v := m.Get("description"); v != "Tier 1" {
+		t.Fatalf("group data source had description %v. should have been Tier 1", v)
+	}
+}
+
+func TestReadGroupDataSourceAmbiguousName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock.NewClient(ctrl)
+	expectGroupPages(c, []zendesk.Group{
+		{ID: 1, Name: "Support"},
+		{ID: 2, Name: "Support"},
+	})
+
+	m := newIdentifiableGetterSetter()
+	m.Set("name", "Support")
+
+	if diags := readGroupDataSource(context.Background(), m, c); !diags.HasError() {
+		t.Fatal("readGroupDataSource should have failed for a name shared by two groups")
+	}
+}
+
+func TestReadGroupDataSourceByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock.NewClient(ctrl)
+	c.EXPECT().GetGroup(gomock.Any(), int64(4)).Return(zendesk.Group{ID: 4, Name: "Workforce", Default: true}, nil)
+
+	m := newIdentifiableGetterSetter()
+	m.Set("id", "4")
+
+	if diags := readGroupDataSource(context.Background(), m, c); len(diags) != 0 {
+		t.Fatalf("readGroupDataSource returned an error: %v", diags)
+	}
+	if v := m.Get("name"); v != "Workforce" {
+		t.Fatalf("group data source had name %v. should have been Workforce", v)
+	}
+	if v := m.Get("default"); v != true {
+		t.Fatalf("group data source had default %v. should have been true", v)
+	}
+}
+
+func TestReadGroupsDataSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := groupsMockClient{
+		Client: mock.NewClient(ctrl),
+		memberships: []newClient.GroupMembership{
+			{ID: 10, UserID: 100, GroupID: 2},
+			{ID: 11, UserID: 101, GroupID: 2},
+			{ID: 12, UserID: 100, GroupID: 3},
+		},
+	}
+	expectGroupPages(c.Client,
+		[]zendesk.Group{
+			{ID: 1, Name: "Billing"},
+			{ID: 2, Name: "WFM Tier 1"},
+		},
+		[]zendesk.Group{
+			{ID: 3, Name: "WFM Tier 2"},
+		},
+	)
+
+	d := newIdentifiableGetterSetter()
+	d.Set("include_deleted", false)
+	d.Set("name_regex", "^WFM")
+
+	if diags := readGroupsDataSource(context.Background(), d, c); len(diags) != 0 {
+		t.Fatalf("readGroupsDataSource returned an error: %v", diags)
+	}
+
+	ids := d.Get("ids").([]string)
+	if !reflect.DeepEqual(ids, []string{"2", "3"}) {
+		t.Fatalf("groups data source returned ids %v. should have been [2 3]", ids)
+	}
+	groups := d.Get("groups").([]map[string]interface{})
+	if v := groups[0]["member_count"]; v != 2 {
+		t.Fatalf("groups data source returned member_count %v. should have been 2", v)
+	}
+	if v := groups[1]["member_count"]; v != 1 {
+		t.Fatalf("groups data source returned member_count %v. should have been 1", v)
+	}
+}
+
+func TestReadGroupsDataSourceIncludeDeleted(t *testing.T) {
+	server := fakezendesk.NewServer()
+	defer server.Close()
+
+	server.Seed("groups", fakezendesk.Object{"name": "Billing"})
+	deleted := server.Seed("groups", fakezendesk.Object{"name": "WFM Tier 2"})
+
+	p := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_url":     server.URL + "/api/v2",
+		"oauth_token": "fake",
+	})
+	meta, diags := providerConfigure(context.Background(), p, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("providerConfigure returned an error: %v", diags)
+	}
+	zd := meta.(*providerMeta).Client
+	if err := zd.DeleteGroup(context.Background(), deleted["id"].(int64)); err != nil {
+		t.Fatalf("could not delete group: %v", err)
+	}
+
+	cases := []struct {
+		includeDeleted bool
+		names          []string
+	}{
+		{false, []string{"Billing"}},
+		{true, []string{"Billing", "WFM Tier 2"}},
+	}
+
+	for _, c := range cases {
+		d := newIdentifiableGetterSetter()
+		d.Set("include_deleted", c.includeDeleted)
+
+		if diags := readGroupsDataSource(context.Background(), d, zd); len(diags) != 0 {
+			t.Fatalf("readGroupsDataSource returned an error: %v", diags)
+		}
+
+		var names []string
+		for _, group := range d.Get("groups").([]map[string]interface{}) {
+			names = append(names, group["name"].(string))
+			if deleted := group["name"] == "WFM Tier 2"; group["deleted"] != deleted {
+				t.Fatalf("groups data source returned deleted %v for %s. should have been %v", group["deleted"], group["name"], deleted)
+			}
+		}
+		if !reflect.DeepEqual(names, c.names) {
+			t.Fatalf("groups data source with include_deleted %v returned %v. should have been %v", c.includeDeleted, names, c.names)
+		}
+	}
+}
+
+func TestGroupsDataSourceMeta(t *testing.T) {
+	var meta interface{} = &newClient.Client{}
+	if _, ok := meta.(groupsAPI); !ok {
+		t.Fatal("the provider client should have implemented groupsAPI")
+	}
+}
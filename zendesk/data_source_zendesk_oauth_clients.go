@@ -0,0 +1,113 @@
+package zendesk
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_clients/#list-clients
+func dataSourceZendeskOAuthClients() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a data source to query the OAuth clients of the account.",
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return readOAuthClientsDataSource(ctx, d, zd)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"clients": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"identifier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kind": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"company": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"redirect_uri": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "List of OAuth clients. Secrets are never exposed.",
+			},
+		},
+	}
+}
+
+func readOAuthClientsDataSource(ctx context.Context, d identifiableGetterSetter, zd newClient.OAuthClientAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	oauthClients, err := zd.GetOAuthClients(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clientList := make([]map[string]interface{}, len(oauthClients))
+	for i, c := range oauthClients {
+		clientList[i] = map[string]interface{}{
+			"id":           int(c.ID),
+			"url":          c.URL,
+			"name":         c.Name,
+			"identifier":   c.Identifier,
+			"kind":         c.Kind,
+			"company":      c.Company,
+			"description":  c.Description,
+			"user_id":      int(c.UserID),
+			"redirect_uri": c.RedirectURI,
+			"created_at":   c.CreatedAt,
+			"updated_at":   c.UpdatedAt,
+		}
+	}
+
+	d.SetId("oauth_clients")
+	err = d.Set("clients", clientList)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,247 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// https://developer.zendesk.com/api-reference/ticketing/business-rules/triggers/#show-trigger
+func dataSourceZendeskTrigger() *schema.Resource {
+	triggerSchema := triggerDataSourceSchema()
+	triggerSchema["id"] = &schema.Schema{
+		Description:  "The ID of the trigger.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "title"},
+	}
+	triggerSchema["title"] = &schema.Schema{
+		Description:  "The title of the trigger. It must match exactly one trigger.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "title"},
+	}
+
+	return &schema.Resource{
+		Description: "Provides a data source to look up a trigger by ID or title.",
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+			zd := i.(client.TriggerAPI)
+			return readTriggerDataSource(ctx, d, zd)
+		},
+
+		Schema: triggerSchema,
+	}
+}
+
+// triggerDataSourceSchema returns the computed attributes set by marshalTrigger
+func triggerDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"title": {
+			Description: "The title of the trigger.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"category_id": {
+			Description: "The ID of the category the trigger belongs to.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"active": {
+			Description: "Whether the trigger is active.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"position": {
+			Description: "Position of the trigger, determines the order they will execute in.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"all": triggerConditionDataSourceSchema("Logical AND. All the conditions must be met."),
+		"any": triggerConditionDataSourceSchema("Logical OR. Any condition can be met."),
+		"action": {
+			Description: "What the trigger will do.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"field": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"value": {
+						Description: "The value of actions which take a single value.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"value_list": {
+						Description: "The value of actions which take a list.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"notification_user": {
+						Description: "The email sent by notification_user and notification_group actions.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"recipient": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"subject": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"body": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+					"notification_webhook": {
+						Description: "The request sent by notification_webhook actions.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"recipient": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"body": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		webhookNotificationKey: {
+			Description: "Webhooks the trigger calls with a JSON payload.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"webhook_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"payload": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"description": {
+			Description: "The description of the trigger.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func triggerConditionDataSourceSchema(desc string) *schema.Schema {
+	return &schema.Schema{
+		Description: desc,
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Description: "The name of a ticket field.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"operator": {
+					Description: "A comparison operator.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"value": {
+					Description: "The value of a ticket field.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// getAllTriggers fetches every page of triggers matching opts
+func getAllTriggers(ctx context.Context, zd client.TriggerAPI, opts client.TriggerListOptions) ([]client.Trigger, error) {
+	var triggers []client.Trigger
+
+	opts.PerPage = 100
+	opts.Page = 1
+	for {
+		page, p, err := zd.GetTriggers(ctx, &opts)
+		if err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, page...)
+
+		if !p.HasNext() {
+			return triggers, nil
+		}
+		opts.Page++
+	}
+}
+
+func readTriggerDataSource(ctx context.Context, d identifiableGetterSetter, zd client.TriggerAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var trigger client.Trigger
+
+	if v, ok := d.GetOk("id"); ok {
+		id, err := atoi64(v.(string))
+		if err != nil {
+			return diag.Errorf("could not parse trigger id %s: %v", v, err)
+		}
+
+		trigger, err = zd.GetTrigger(ctx, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		title := d.Get("title").(string)
+		triggers, err := getAllTriggers(ctx, zd, client.TriggerListOptions{})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		var matches []client.Trigger
+		for _, t := range triggers {
+			if t.Title == title {
+				matches = append(matches, t)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("no trigger with title %q found", title)
+		case 1:
+			trigger = matches[0]
+		default:
+			return diag.Errorf("%d triggers have the title %q, look the trigger up by id instead", len(matches), title)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d", trigger.ID))
+	err := marshalTrigger(trigger, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,158 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// https://developer.zendesk.com/api-reference/ticketing/business-rules/triggers/#list-triggers
+func dataSourceZendeskTriggers() *schema.Resource {
+	triggerSchema := triggerDataSourceSchema()
+	triggerSchema["id"] = &schema.Schema{
+		Description: "The ID of the trigger.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Provides a data source to list the triggers of the account, including those managed outside of Terraform.",
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+			zd := i.(client.TriggerAPI)
+			return readTriggersDataSource(ctx, d, zd)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"active": {
+				Description: "Only return active triggers if true, or inactive triggers if false.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"category_id": {
+				Description: "Only return triggers of this trigger category.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"sort_by": {
+				Description: "Sort triggers by this attribute. Allowed values are \"alphabetical\", \"created_at\", \"updated_at\", \"usage_1h\", \"usage_1d\", \"usage_7d\" or \"usage_30d\". Defaults to position.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"alphabetical",
+					"created_at",
+					"updated_at",
+					"usage_1h",
+					"usage_1d",
+					"usage_7d",
+					"usage_30d",
+				}, false),
+			},
+			"sort_order": {
+				Description:  "Sort order, \"asc\" or \"desc\".",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"asc", "desc"}, false),
+			},
+			"title_regex": {
+				Description:  "Only return triggers whose title matches this regular expression.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"ids": {
+				Description: "The IDs of the matching triggers, in the requested order.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"triggers": {
+				Description: "The matching triggers, in the requested order.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: triggerSchema,
+				},
+			},
+		},
+	}
+}
+
+func readTriggersDataSource(ctx context.Context, d identifiableGetterSetter, zd client.TriggerAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	opts := client.TriggerListOptions{}
+
+	// the API drops active=false, so inactive triggers are filtered below
+	active, filterActive := getBoolOk(d, "active")
+	if filterActive && active {
+		opts.Active = true
+	}
+
+	if v, ok := d.GetOk("category_id"); ok {
+		opts.CategoryID = v.(string)
+	}
+
+	if v, ok := d.GetOk("sort_by"); ok {
+		opts.SortBy = v.(string)
+	}
+
+	if v, ok := d.GetOk("sort_order"); ok {
+		opts.SortOrder = v.(string)
+	}
+
+	var titleRegex *regexp.Regexp
+	if v, ok := d.GetOk("title_regex"); ok {
+		re, err := regexp.Compile(v.(string))
+		if err != nil {
+			return diag.Errorf("could not parse title_regex %s: %v", v, err)
+		}
+		titleRegex = re
+	}
+
+	triggers, err := getAllTriggers(ctx, zd, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	triggerList := []map[string]interface{}{}
+	for _, trigger := range triggers {
+		if filterActive && trigger.Active != active {
+			continue
+		}
+		if titleRegex != nil && !titleRegex.MatchString(trigger.Title) {
+			continue
+		}
+
+		m := &identifiableMapGetterSetter{mapGetterSetter: make(mapGetterSetter)}
+		err := marshalTrigger(trigger, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		id := fmt.Sprintf("%d", trigger.ID)
+		m.mapGetterSetter["id"] = id
+
+		ids = append(ids, id)
+		triggerList = append(triggerList, m.mapGetterSetter)
+	}
+
+	d.SetId("triggers")
+	err = d.Set("ids", ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("triggers", triggerList)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,107 @@
+package zendesk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nukosuke/go-zendesk/zendesk"
+	"github.com/nukosuke/go-zendesk/zendesk/mock"
+)
+
+func expectTriggerPages(c *mock.Client, pages ...[]zendesk.Trigger) {
+	next := "next"
+	for i, page := range pages {
+		p := zendesk.Page{}
+		if i < len(pages)-1 {
+			p.NextPage = &next
+		}
+
+		pageNumber := i + 1
+		c.EXPECT().GetTriggers(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, opts *zendesk.TriggerListOptions) ([]zendesk.Trigger, zendesk.Page, error) {
+				if opts.Page != pageNumber {
+					return nil, zendesk.Page{}, &zendesk.OptionsError{}
+				}
+				return page, p, nil
+			})
+	}
+}
+
+func TestReadTriggerDataSourceByTitle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock.NewClient(ctrl)
+	expectTriggerPages(c,
+		[]zendesk.Trigger{{ID: 1, Title: "Auto reply"}},
+		[]zendesk.Trigger{{ID: 2, Title: "Escalate VIP", Active: true}},
+	)
+
+	m := newIdentifiableGetterSetter()
+	m.Set("title", "Escalate VIP")
+
+	if diags := readTriggerDataSource(context.Background(), m, c); len(diags) != 0 {
+		t.Fatalf("readTriggerDataSource returned an error: %v", diags)
+	}
+	if v := m.Id(); v != "2" {
+		t.Fatalf("trigger data source had id %s. should have been 2", v)
+	}
+	if v := m.Get("active"); v != true {
+		t.Fatalf("trigger data source had active %v. should have been true", v)
+	}
+}
+
+func TestReadTriggerDataSourceAmbiguousTitle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock.NewClient(ctrl)
+	expectTriggerPages(c, []zendesk.Trigger{
+		{ID: 1, Title: "Escalate VIP"},
+		{ID: 2, Title: "Escalate VIP"},
+	})
+
+	m := newIdentifiableGetterSetter()
+	m.Set("title", "Escalate VIP")
+
+	if diags := readTriggerDataSource(context.Background(), m, c); !diags.HasError() {
+		t.Fatal("readTriggerDataSource should have failed for a title shared by two triggers")
+	}
+}
+
+func TestReadTriggersDataSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock.NewClient(ctrl)
+	expectTriggerPages(c,
+		[]zendesk.Trigger{
+			{ID: 1, Title: "Auto reply", Active: true},
+			{ID: 2, Title: "Escalate VIP", Active: false},
+		},
+		[]zendesk.Trigger{
+			{ID: 3, Title: "Escalate billing", Active: true},
+		},
+	)
+
+	d := newIdentifiableGetterSetter()
+	d.Set("active", false)
+	d.Set("title_regex", "^Escalate")
+
+	if diags := readTriggersDataSource(context.Background(), d, c); len(diags) != 0 {
+		t.Fatalf("readTriggersDataSource returned an error: %v", diags)
+	}
+
+	ids := d.Get("ids").([]string)
+	if len(ids) != 1 || ids[0] != "2" {
+		t.Fatalf("triggers data source returned ids %v. should have been [2]", ids)
+	}
+	triggers := d.Get("triggers").([]map[string]interface{})
+	if v := triggers[0]["title"]; v != "Escalate VIP" {
+		t.Fatalf("triggers data source returned title %v. should have been Escalate VIP", v)
+	}
+	if v := triggers[0]["id"]; v != "2" {
+		t.Fatalf("triggers data source returned id %#v. should have been the string 2 like zendesk_trigger", v)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/nukosuke/go-zendesk/zendesk"
 	"github.com/nukosuke/go-zendesk/zendesk/mock"
+	"github.com/nukosuke/terraform-provider-zendesk/internal/fakezendesk"
 )
 
 const webhookConfig = `
@@ -89,6 +90,9 @@ func TestAccWebhookDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
+			if testAccFakeServer != nil {
+				testAccFakeServer.Seed("webhooks", fakezendesk.Object{"id": "1234", "name": "Existing webhook"})
+			}
 		},
 		Providers: testAccProviders,
 		Steps: []resource.TestStep{
@@ -0,0 +1,379 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+	"github.com/nukosuke/terraform-provider-zendesk/zendesk/models"
+)
+
+// exportedObject is a Zendesk object marshalled into the resource data of its resource type
+type exportedObject struct {
+	id       string
+	name     string
+	position int
+	data     *schema.ResourceData
+}
+
+// exportSpec describes how the objects of a resource type are listed
+type exportSpec struct {
+	resourceType string
+	resource     func() *schema.Resource
+	// collection is set for types which have an order resource
+	collection *newClient.OrderedCollection
+	list       func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error)
+}
+
+// exportSpecs lists the exported resource types. Types are listed before the types referring to them.
+var exportSpecs = []exportSpec{
+	{
+		resourceType: "zendesk_brand",
+		resource:     resourceZendeskBrand,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/brands.json", "brands", false,
+				func(b client.Brand) (string, string, bool) { return strconv.FormatInt(b.ID, 10), b.Name, false },
+				marshalBrand)
+		},
+	},
+	{
+		resourceType: "zendesk_group",
+		resource:     resourceZendeskGroup,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/groups.json", "groups", false,
+				func(g client.Group) (string, string, bool) { return strconv.FormatInt(g.ID, 10), g.Name, g.Deleted },
+				marshalGroup)
+		},
+	},
+	{
+		resourceType: "zendesk_ticket_field",
+		resource:     resourceZendeskTicketField,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			// system fields such as subject and status can't be managed
+			return exportAll(ctx, zd, r, "/ticket_fields.json", "ticket_fields", false,
+				func(f client.TicketField) (string, string, bool) {
+					return strconv.FormatInt(f.ID, 10), f.Title, !f.Removable
+				},
+				marshalTicketField)
+		},
+	},
+	{
+		resourceType: "zendesk_user_field",
+		resource:     resourceZendeskUserField,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/user_fields.json", "user_fields", false,
+				func(f UserField) (string, string, bool) { return strconv.FormatInt(f.ID, 10), f.Key, f.System },
+				marshalUserField)
+		},
+	},
+	{
+		resourceType: "zendesk_organization_field",
+		resource:     resourceZendeskOrganizationField,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/organization_fields.json", "organization_fields", false,
+				func(f client.OrganizationField) (string, string, bool) {
+					return strconv.FormatInt(f.ID, 10), f.Key, f.System
+				},
+				marshalOrganizationField)
+		},
+	},
+	{
+		resourceType: "zendesk_ticket_form",
+		resource:     resourceZendeskTicketForm,
+		collection:   &newClient.TicketFormCollection,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/ticket_forms.json", "ticket_forms", false,
+				func(f models.TicketForm) (string, string, bool) { return strconv.FormatInt(f.ID, 10), f.Name, false },
+				marshalTicketForm)
+		},
+	},
+	{
+		resourceType: "zendesk_webhook",
+		resource:     resourceZendeskWebhook,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/webhooks", "webhooks", true,
+				func(w client.Webhook) (string, string, bool) { return w.ID, w.Name, false },
+				func(w client.Webhook, d identifiableGetterSetter) error { return marshalWebhook(&w, d) })
+		},
+	},
+	{
+		resourceType: "zendesk_trigger",
+		resource:     resourceZendeskTrigger,
+		collection:   &newClient.TriggerCollection,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/triggers.json", "triggers", false,
+				func(t client.Trigger) (string, string, bool) { return strconv.FormatInt(t.ID, 10), t.Title, false },
+				marshalTrigger)
+		},
+	},
+	{
+		resourceType: "zendesk_automation",
+		resource:     resourceZendeskAutomation,
+		collection:   &newClient.AutomationCollection,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/automations.json", "automations", false,
+				func(a client.Automation) (string, string, bool) { return strconv.FormatInt(a.ID, 10), a.Title, false },
+				marshalAutomation)
+		},
+	},
+	{
+		resourceType: "zendesk_macro",
+		resource:     resourceZendeskMacro,
+		collection:   &newClient.MacroCollection,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/macros.json", "macros", false,
+				func(m models.Macro) (string, string, bool) { return strconv.FormatInt(m.ID, 10), m.Title, false },
+				marshalMacros)
+		},
+	},
+	{
+		resourceType: "zendesk_view",
+		resource:     resourceZendeskView,
+		collection:   &newClient.ViewCollection,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/views.json", "views", false,
+				func(v models.View) (string, string, bool) { return strconv.FormatInt(v.ID, 10), v.Title, false },
+				marshalViews)
+		},
+	},
+	{
+		resourceType: "zendesk_sla_policy",
+		resource:     resourceZendeskSLAPolicy,
+		collection:   &newClient.SLAPolicyCollection,
+		list: func(ctx context.Context, zd *newClient.Client, r *schema.Resource) ([]exportedObject, error) {
+			return exportAll(ctx, zd, r, "/slas/policies.json", "sla_policies", false,
+				func(p client.SLAPolicy) (string, string, bool) { return strconv.FormatInt(p.ID, 10), p.Title, false },
+				marshalSLAPolicy)
+		},
+	},
+}
+
+// ExportTypes returns the resource types which Export supports
+func ExportTypes() []string {
+	types := make([]string, len(exportSpecs))
+	for i, spec := range exportSpecs {
+		types[i] = spec.resourceType
+	}
+	return types
+}
+
+// Export reads the objects of the given resource types from Zendesk and returns
+// Terraform configuration for them, keyed by file name. Every resource type is
+// written to its own file, and imports.tf holds an import block for each
+// resource. IDs of exported objects are replaced with references to their
+// resources.
+func Export(ctx context.Context, zd *newClient.Client, types []string) (map[string][]byte, error) {
+	var specs []exportSpec
+	for _, t := range types {
+		found := false
+		for _, spec := range exportSpecs {
+			if spec.resourceType == t {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("resource type %s can't be exported, supported types are %s", t, strings.Join(ExportTypes(), ", "))
+		}
+	}
+	for _, spec := range exportSpecs {
+		if containsString(types, spec.resourceType) {
+			specs = append(specs, spec)
+		}
+	}
+
+	objects := map[string][]exportedObject{}
+	index := exportIndex{}
+	for _, spec := range specs {
+		list, err := spec.list(ctx, zd, spec.resource())
+		if err != nil {
+			return nil, fmt.Errorf("could not list %s: %v", spec.resourceType, err)
+		}
+
+		names := map[string]bool{}
+		for i := range list {
+			list[i].name = exportResourceName(list[i].name, list[i].id, names)
+			index.add(spec.resourceType, list[i])
+		}
+		objects[spec.resourceType] = list
+	}
+
+	files := map[string][]byte{}
+	imports := newExportFile()
+	for _, spec := range specs {
+		r := spec.resource()
+		f := newExportFile()
+
+		// positions of types with an order resource are written there instead
+		skipped := exportSkippedAttributes
+		if spec.collection != nil {
+			skipped = append([]string{"position"}, skipped...)
+		}
+
+		for _, o := range objects[spec.resourceType] {
+			attributes := exportAttributes(r.Schema, o.data, skipped)
+			index.rewrite(attributes)
+
+			f.addResource(spec.resourceType, o.name, r.Schema, attributes)
+			imports.addImport(spec.resourceType, o.name, o.id)
+		}
+
+		if spec.collection != nil && len(objects[spec.resourceType]) > 0 {
+			orderType := spec.resourceType + "_order"
+			f.addOrder(orderType, spec.resourceType, objects[spec.resourceType])
+			imports.addImport(orderType, "all", spec.collection.Name)
+		}
+
+		files[strings.TrimPrefix(spec.resourceType, "zendesk_")+".tf"] = f.bytes()
+	}
+	files["imports.tf"] = imports.bytes()
+
+	return files, nil
+}
+
+// exportAll lists every object at path and marshals the ones which describe doesn't skip
+func exportAll[T any](ctx context.Context, zd *newClient.Client, r *schema.Resource, path, key string, cursor bool,
+	describe func(T) (string, string, bool), marshal func(T, identifiableGetterSetter) error) ([]exportedObject, error) {
+	list, err := listAll[T](ctx, zd, path, key, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, v := range list {
+		id, name, skip := describe(v)
+		if skip {
+			continue
+		}
+
+		d := r.Data(nil)
+		d.SetId(id)
+		err := marshal(v, d)
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal %s: %v", id, err)
+		}
+
+		o := exportedObject{id: id, name: name, data: d}
+		if _, ok := r.Schema["position"]; ok {
+			o.position = d.Get("position").(int)
+		}
+		objects = append(objects, o)
+	}
+
+	return objects, nil
+}
+
+// listAll fetches every page of the collection at path, whose objects are under key.
+// Collections which only support cursor pagination set cursor.
+func listAll[T any](ctx context.Context, zd *newClient.Client, path, key string, cursor bool) ([]T, error) {
+	var objects []T
+
+	page := 1
+	query := "page=1&per_page=100"
+	if cursor {
+		query = "page[size]=100"
+	}
+
+	for {
+		body, err := zd.Get(ctx, path+"?"+query)
+		if err != nil {
+			return nil, err
+		}
+
+		var data map[string]json.RawMessage
+		err = json.Unmarshal(body, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageObjects []T
+		err = json.Unmarshal(data[key], &pageObjects)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s: %v", key, err)
+		}
+		objects = append(objects, pageObjects...)
+
+		var next struct {
+			NextPage *string `json:"next_page"`
+			Meta     struct {
+				HasMore     bool   `json:"has_more"`
+				AfterCursor string `json:"after_cursor"`
+			} `json:"meta"`
+		}
+		err = json.Unmarshal(body, &next)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case next.Meta.HasMore && next.Meta.AfterCursor != "":
+			query = "page[size]=100&page[after]=" + url.QueryEscape(next.Meta.AfterCursor)
+		case !cursor && next.NextPage != nil && *next.NextPage != "" && len(pageObjects) > 0:
+			page++
+			query = fmt.Sprintf("page=%d&per_page=100", page)
+		default:
+			return objects, nil
+		}
+	}
+}
+
+var exportNameInvalid = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportResourceName turns the title of an object into a unique resource name
+func exportResourceName(title, id string, used map[string]bool) string {
+	name := strings.Trim(exportNameInvalid.ReplaceAllString(strings.ToLower(title), "_"), "_")
+	if name == "" {
+		name = "id_" + exportNameInvalid.ReplaceAllString(strings.ToLower(id), "_")
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[unique] = true
+
+	return unique
+}
+
+// sortedByPosition returns the objects in the order of their positions
+func sortedByPosition(objects []exportedObject) []exportedObject {
+	sorted := append([]exportedObject(nil), objects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].position < sorted[j].position
+	})
+	return sorted
+}
+
+// ConfigFromEnv returns the provider configuration set by the ZENDESK_* environment variables
+func ConfigFromEnv() Config {
+	return Config{
+		Account:      os.Getenv(accountVar),
+		Email:        os.Getenv(emailVar),
+		Token:        os.Getenv(tokenVar),
+		OAuthToken:   os.Getenv(oauthTokenVar),
+		ClientID:     os.Getenv(clientIDVar),
+		ClientSecret: os.Getenv(clientSecretVar),
+		APIURL:       os.Getenv(apiURLVar),
+		ProxyURL:     os.Getenv(proxyURLVar),
+		CABundleFile: os.Getenv(caBundleVar),
+		MaxRetries:   newClient.DefaultMaxRetries,
+		RetryMaxWait: newClient.DefaultRetryMaxWait,
+	}
+}
+
+// NewClient creates an API client configured like the provider, for tools built on this package
+func NewClient(ctx context.Context, config Config, userAgent string) (*newClient.Client, diag.Diagnostics) {
+	return newZendeskClient(ctx, config, userAgent)
+}
@@ -0,0 +1,361 @@
+package zendesk
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// exportSkippedAttributes aren't written for any resource
+var exportSkippedAttributes = []string{"id", "account"}
+
+var (
+	exportCustomFieldPattern     = regexp.MustCompile(`^custom_fields_(\d+)$`)
+	exportCustomUserFieldPattern = regexp.MustCompile(`^(requester|organization)\.custom_fields\.(.+)$`)
+)
+
+// exportReference is written as a reference to an attribute of another
+// resource, inside a string template when prefix is set
+type exportReference struct {
+	prefix       string
+	resourceType string
+	name         string
+	attribute    string
+}
+
+func (r exportReference) tokens() hclwrite.Tokens {
+	traversal := hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: r.resourceType},
+		hcl.TraverseAttr{Name: r.name},
+		hcl.TraverseAttr{Name: r.attribute},
+	})
+	if r.prefix == "" {
+		return traversal
+	}
+
+	// the quoted literal of the prefix, without its quotes
+	prefix := hclwrite.TokensForValue(cty.StringVal(r.prefix))
+	tokens := hclwrite.Tokens{prefix[0], prefix[1]}
+	tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")})
+	tokens = append(tokens, traversal...)
+	tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")})
+	tokens = append(tokens, prefix[len(prefix)-1])
+	return tokens
+}
+
+// exportIndex finds the resource names of exported objects by ID, and of
+// user and organization fields by key
+type exportIndex struct {
+	names map[string]map[string]string
+	keys  map[string]map[string]string
+}
+
+func (x *exportIndex) add(resourceType string, o exportedObject) {
+	if x.names == nil {
+		x.names = map[string]map[string]string{}
+		x.keys = map[string]map[string]string{}
+	}
+	if x.names[resourceType] == nil {
+		x.names[resourceType] = map[string]string{}
+		x.keys[resourceType] = map[string]string{}
+	}
+
+	x.names[resourceType][o.id] = o.name
+	if key, ok := o.data.GetOk("key"); ok {
+		x.keys[resourceType][key.(string)] = o.name
+	}
+}
+
+// reference returns a reference to the exported object with ID v, or v if it wasn't exported
+func (x *exportIndex) reference(resourceType string, v interface{}) interface{} {
+	name, ok := x.names[resourceType][fmt.Sprintf("%v", v)]
+	if !ok {
+		return v
+	}
+	return exportReference{resourceType: resourceType, name: name, attribute: "id"}
+}
+
+func (x *exportIndex) references(resourceType string, v interface{}) interface{} {
+	list, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	refs := make([]interface{}, len(list))
+	for i, id := range list {
+		refs[i] = x.reference(resourceType, id)
+	}
+	return refs
+}
+
+// rewrite replaces IDs of exported objects in attributes with references to their resources
+func (x *exportIndex) rewrite(attributes map[string]interface{}) {
+	x.rewriteRule(attributes)
+
+	for k, v := range attributes {
+		switch k {
+		case "webhook_id":
+			attributes[k] = x.reference("zendesk_webhook", v)
+		case "parent_field_id":
+			attributes[k] = x.reference("zendesk_ticket_field", v)
+		case "ticket_field_ids":
+			attributes[k] = x.references("zendesk_ticket_field", v)
+		case "restricted_brand_ids":
+			attributes[k] = x.references("zendesk_brand", v)
+		case "restrictions":
+			attributes[k] = x.references("zendesk_group", v)
+		}
+
+		list, ok := attributes[k].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, e := range list {
+			block, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if k == "child_fields" {
+				block["id"] = x.reference("zendesk_ticket_field", block["id"])
+			}
+			x.rewrite(block)
+		}
+	}
+}
+
+// rewriteRule rewrites the field and value of a condition or action block
+func (x *exportIndex) rewriteRule(block map[string]interface{}) {
+	field, ok := block["field"].(string)
+	if !ok {
+		return
+	}
+
+	switch field {
+	case "group_id":
+		block["value"] = x.reference("zendesk_group", block["value"])
+	case "brand_id":
+		block["value"] = x.reference("zendesk_brand", block["value"])
+	case "ticket_form_id":
+		block["value"] = x.reference("zendesk_ticket_form", block["value"])
+	case "notification_group":
+		x.rewriteRecipient(block[actionNotificationUser], "zendesk_group")
+	case "notification_webhook":
+		x.rewriteRecipient(block[actionNotificationWebhook], "zendesk_webhook")
+	}
+
+	if m := exportCustomFieldPattern.FindStringSubmatch(field); m != nil {
+		if name, ok := x.names["zendesk_ticket_field"][m[1]]; ok {
+			block["field"] = exportReference{prefix: "custom_fields_", resourceType: "zendesk_ticket_field", name: name, attribute: "id"}
+		}
+	}
+
+	if m := exportCustomUserFieldPattern.FindStringSubmatch(field); m != nil {
+		resourceType := "zendesk_user_field"
+		if m[1] == "organization" {
+			resourceType = "zendesk_organization_field"
+		}
+		if name, ok := x.keys[resourceType][m[2]]; ok {
+			block["field"] = exportReference{prefix: m[1] + ".custom_fields.", resourceType: resourceType, name: name, attribute: "key"}
+		}
+	}
+}
+
+func (x *exportIndex) rewriteRecipient(v interface{}, resourceType string) {
+	blocks, _ := v.([]interface{})
+	for _, b := range blocks {
+		if block, ok := b.(map[string]interface{}); ok {
+			block["recipient"] = x.reference(resourceType, block["recipient"])
+		}
+	}
+}
+
+// exportAttributes returns the configurable attributes of d which differ from
+// their defaults, except for skipped ones
+func exportAttributes(s map[string]*schema.Schema, d *schema.ResourceData, skipped []string) map[string]interface{} {
+	values := map[string]interface{}{}
+	for k := range s {
+		if !containsString(skipped, k) {
+			values[k] = d.Get(k)
+		}
+	}
+	return exportBlock(s, values)
+}
+
+func exportBlock(s map[string]*schema.Schema, values map[string]interface{}) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for k, v := range values {
+		sch, ok := s[k]
+		if !ok || (!sch.Optional && !sch.Required) || sch.Sensitive || sch.Deprecated != "" {
+			continue
+		}
+
+		if set, ok := v.(*schema.Set); ok {
+			v = set.List()
+		}
+		if elem, ok := sch.Elem.(*schema.Resource); ok {
+			list, _ := v.([]interface{})
+			blocks := []interface{}{}
+			for _, e := range list {
+				if m, ok := e.(map[string]interface{}); ok {
+					blocks = append(blocks, exportBlock(elem.Schema, m))
+				}
+			}
+			v = blocks
+		}
+
+		if !sch.Required && exportIsDefault(sch, v) {
+			continue
+		}
+		attributes[k] = v
+	}
+	return attributes
+}
+
+func exportIsDefault(sch *schema.Schema, v interface{}) bool {
+	if sch.Default != nil {
+		return reflect.DeepEqual(sch.Default, v)
+	}
+
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// exportFile is a .tf file written by Export
+type exportFile struct {
+	f *hclwrite.File
+}
+
+func newExportFile() *exportFile {
+	return &exportFile{f: hclwrite.NewEmptyFile()}
+}
+
+func (e *exportFile) newBlock(blockType string, labels []string) *hclwrite.Body {
+	body := e.f.Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	return body.AppendNewBlock(blockType, labels).Body()
+}
+
+func (e *exportFile) addResource(resourceType, name string, s map[string]*schema.Schema, attributes map[string]interface{}) {
+	writeExportBody(e.newBlock("resource", []string{resourceType, name}), s, attributes)
+}
+
+func (e *exportFile) addImport(resourceType, name, id string) {
+	body := e.newBlock("import", nil)
+	body.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+	})
+	body.SetAttributeValue("id", cty.StringVal(id))
+}
+
+// addOrder adds the order resource of the objects, listed by position
+func (e *exportFile) addOrder(orderType, resourceType string, objects []exportedObject) {
+	var ids []hclwrite.Tokens
+	for _, o := range sortedByPosition(objects) {
+		ids = append(ids, exportReference{resourceType: resourceType, name: o.name, attribute: "id"}.tokens())
+	}
+
+	body := e.newBlock("resource", []string{orderType, "all"})
+	body.SetAttributeRaw("ids", hclwrite.TokensForTuple(ids))
+}
+
+func (e *exportFile) bytes() []byte {
+	return hclwrite.Format(e.f.Bytes())
+}
+
+// writeExportBody writes attributes first and nested blocks after them, both sorted by name
+func writeExportBody(body *hclwrite.Body, s map[string]*schema.Schema, attributes map[string]interface{}) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, isBlock := s[k].Elem.(*schema.Resource); isBlock {
+			continue
+		}
+
+		if s[k].Sensitive && s[k].Required {
+			body.AppendUnstructuredTokens(hclwrite.Tokens{{
+				Type:  hclsyntax.TokenComment,
+				Bytes: []byte(fmt.Sprintf("# %s is sensitive and isn't returned by Zendesk, set it before applying\n", k)),
+			}})
+			continue
+		}
+
+		if v, ok := attributes[k]; ok {
+			body.SetAttributeRaw(k, exportTokens(v))
+		}
+	}
+
+	for _, k := range keys {
+		elem, isBlock := s[k].Elem.(*schema.Resource)
+		if !isBlock {
+			continue
+		}
+
+		blocks, _ := attributes[k].([]interface{})
+		for _, b := range blocks {
+			writeExportBody(body.AppendNewBlock(k, nil).Body(), elem.Schema, b.(map[string]interface{}))
+		}
+	}
+}
+
+// exportTokens returns the HCL expression of an attribute value
+func exportTokens(v interface{}) hclwrite.Tokens {
+	switch value := v.(type) {
+	case exportReference:
+		return value.tokens()
+	case string:
+		return hclwrite.TokensForValue(cty.StringVal(value))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(value))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(value)))
+	case int64:
+		return hclwrite.TokensForValue(cty.NumberIntVal(value))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(value))
+	case []interface{}:
+		elems := make([]hclwrite.Tokens, len(value))
+		for i, e := range value {
+			elems[i] = exportTokens(e)
+		}
+		return hclwrite.TokensForTuple(elems)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		attrs := make([]hclwrite.ObjectAttrTokens, len(keys))
+		for i, k := range keys {
+			name := hclwrite.TokensForValue(cty.StringVal(k))
+			if hclsyntax.ValidIdentifier(k) {
+				name = hclwrite.TokensForIdentifier(k)
+			}
+			attrs[i] = hclwrite.ObjectAttrTokens{Name: name, Value: exportTokens(value[k])}
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+
+	return hclwrite.TokensForValue(cty.StringVal(fmt.Sprintf("%v", v)))
+}
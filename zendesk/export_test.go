@@ -0,0 +1,101 @@
+package zendesk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	responses := map[string]string{
+		"/api/v2/groups.json":        `{"groups":[{"id":10,"name":"Tier 2"},{"id":11,"name":"Old","deleted":true}],"next_page":null}`,
+		"/api/v2/ticket_fields.json": `{"ticket_fields":[{"id":20,"type":"text","title":"Order number","position":3,"removable":true},{"id":21,"type":"subject","title":"Subject","removable":false}],"next_page":null}`,
+		"/api/v2/webhooks":           `{"webhooks":[{"id":"01GWEBHOOK","name":"Slack","endpoint":"https://example.com/hook","http_method":"POST","request_format":"json","status":"active","subscriptions":["conditional_ticket_events"]}],"meta":{"has_more":false}}`,
+		"/api/v2/triggers.json": `{"triggers":[
+			{"id":31,"title":"Second","position":2,"active":true,"conditions":{"all":[{"field":"group_id","operator":"is","value":"10"}]},"actions":[{"field":"status","value":"open"}]},
+			{"id":30,"title":"First","position":1,"active":true,"conditions":{"all":[{"field":"custom_fields_20","operator":"present","value":""}]},"actions":[{"field":"notification_webhook","value":["01GWEBHOOK","{\"text\":\"hi\"}"]}]}
+		],"next_page":null}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	zd, diags := NewClient(context.Background(), Config{APIURL: server.URL + "/api/v2", OAuthToken: "xxx"}, "zendesk-tf-export/test")
+	if diags.HasError() {
+		t.Fatalf("NewClient returned an error: %v", diags)
+	}
+
+	files, err := Export(context.Background(), zd, []string{"zendesk_trigger", "zendesk_group", "zendesk_ticket_field", "zendesk_webhook"})
+	if err != nil {
+		t.Fatalf("Export returned an error: %v", err)
+	}
+
+	for name, contents := range map[string][]string{
+		"group.tf":        {`resource "zendesk_group" "tier_2" {`, `name = "Tier 2"`},
+		"ticket_field.tf": {`resource "zendesk_ticket_field" "order_number" {`, `position = 3`},
+		"trigger.tf": {
+			`value    = zendesk_group.tier_2.id`,
+			`field    = "custom_fields_${zendesk_ticket_field.order_number.id}"`,
+			`webhook_id = zendesk_webhook.slack.id`,
+			`ids = [zendesk_trigger.first.id, zendesk_trigger.second.id]`,
+		},
+		"imports.tf": {
+			"to = zendesk_group.tier_2\n  id = \"10\"",
+			"to = zendesk_webhook.slack\n  id = \"01GWEBHOOK\"",
+			"to = zendesk_trigger_order.all\n  id = \"triggers\"",
+		},
+	} {
+		file, ok := files[name]
+		if !ok {
+			t.Fatalf("Export did not write %s", name)
+		}
+		for _, s := range contents {
+			if !strings.Contains(string(file), s) {
+				t.Fatalf("%s did not contain %q:\n%s", name, s, file)
+			}
+		}
+	}
+
+	if strings.Contains(string(files["trigger.tf"]), "position") {
+		t.Fatalf("Export should have written the positions of triggers to zendesk_trigger_order:\n%s", files["trigger.tf"])
+	}
+
+	if strings.Contains(string(files["group.tf"]), "Old") || strings.Contains(string(files["ticket_field.tf"]), "Subject") {
+		t.Fatalf("Export should have skipped deleted groups and system fields:\n%s%s", files["group.tf"], files["ticket_field.tf"])
+	}
+}
+
+func TestExportUnknownType(t *testing.T) {
+	_, err := Export(context.Background(), nil, []string{"zendesk_ticket"})
+	if err == nil {
+		t.Fatalf("Export of zendesk_ticket should have returned an error")
+	}
+}
+
+func TestExportResourceName(t *testing.T) {
+	used := map[string]bool{}
+	cases := []struct {
+		title    string
+		id       string
+		expected string
+	}{
+		{"Escalate VIP", "1", "escalate_vip"},
+		{"Escalate  VIP!", "2", "escalate_vip_2"},
+		{"2nd line", "3", "_2nd_line"},
+		{"???", "4", "id_4"},
+	}
+
+	for _, c := range cases {
+		if name := exportResourceName(c.title, c.id, used); name != c.expected {
+			t.Fatalf("name of %s was %s. should have been %s", c.title, name, c.expected)
+		}
+	}
+}
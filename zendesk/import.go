@@ -0,0 +1,63 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// importByAttribute returns an importer which accepts import IDs of the form
+// <attribute>:<value>, e.g. title:Escalate VIP, besides plain IDs. The objects
+// at path, listed under key, are searched for the only one whose attribute
+// has the value.
+func importByAttribute[T any](path, key string, id func(T) int64, attributes map[string]func(T) string) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			attribute, value, found := strings.Cut(d.Id(), ":")
+			if !found {
+				return []*schema.ResourceData{d}, nil
+			}
+
+			get, ok := attributes[attribute]
+			if !ok {
+				return nil, fmt.Errorf("import ID %q should be an ID or one of %s", d.Id(), importForms(attributes))
+			}
+
+			objects, err := listAll[T](ctx, meta.(*newClient.Client), path, key, false)
+			if err != nil {
+				return nil, err
+			}
+
+			var ids []string
+			for _, o := range objects {
+				if get(o) == value {
+					ids = append(ids, strconv.FormatInt(id(o), 10))
+				}
+			}
+
+			noun := strings.ReplaceAll(key, "_", " ")
+			switch len(ids) {
+			case 0:
+				return nil, fmt.Errorf("no %s have %s %q", noun, attribute, value)
+			case 1:
+				d.SetId(ids[0])
+				return []*schema.ResourceData{d}, nil
+			}
+			return nil, fmt.Errorf("%d %s have %s %q, import one of them by ID instead: %s", len(ids), noun, attribute, value, strings.Join(ids, ", "))
+		},
+	}
+}
+
+func importForms[T any](attributes map[string]func(T) string) string {
+	var forms []string
+	for attribute := range attributes {
+		forms = append(forms, fmt.Sprintf("%s:<%s>", attribute, attribute))
+	}
+	sort.Strings(forms)
+	return strings.Join(forms, ", ")
+}
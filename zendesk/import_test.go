@@ -0,0 +1,63 @@
+package zendesk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestImportByAttribute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/triggers.json":
+			w.Write([]byte(`{"triggers":[{"id":1,"title":"Escalate VIP"},{"id":2,"title":"Auto reply"},{"id":3,"title":"Auto reply"}],"next_page":null}`))
+		case "/api/v2/user_fields.json":
+			w.Write([]byte(`{"user_fields":[{"id":4,"key":"account_tier","title":"Account tier"}],"next_page":null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	zd, diags := NewClient(context.Background(), Config{APIURL: server.URL + "/api/v2", OAuthToken: "xxx"}, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("NewClient returned an error: %v", diags)
+	}
+
+	cases := []struct {
+		resourceType string
+		importID     string
+		expectedID   string
+		expectedErr  string
+	}{
+		{"zendesk_trigger", "10", "10", ""},
+		{"zendesk_trigger", "title:Escalate VIP", "1", ""},
+		{"zendesk_user_field", "key:account_tier", "4", ""},
+		{"zendesk_user_field", "title:Account tier", "4", ""},
+		{"zendesk_trigger", "title:Auto reply", "", "2 triggers have title \"Auto reply\", import one of them by ID instead: 2, 3"},
+		{"zendesk_trigger", "title:Missing", "", "no triggers have title \"Missing\""},
+		{"zendesk_trigger", "name:Escalate VIP", "", "should be an ID or one of title:<title>"},
+	}
+
+	for _, c := range cases {
+		r := Provider().ResourcesMap[c.resourceType]
+		d := r.TestResourceData()
+		d.SetId(c.importID)
+
+		result, err := r.Importer.StateContext(context.Background(), d, &providerMeta{Client: zd})
+		if c.expectedErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expectedErr) {
+				t.Fatalf("import of %s returned error %v. should have been %s", c.importID, err, c.expectedErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("import of %s returned an error: %v", c.importID, err)
+		}
+		if len(result) != 1 || result[0].Id() != c.expectedID {
+			t.Fatalf("import of %s had id %s. should have been %s", c.importID, d.Id(), c.expectedID)
+		}
+	}
+}
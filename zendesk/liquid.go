@@ -0,0 +1,347 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// liquidBlockTags maps the Liquid tags which open a block to the tag closing it
+var liquidBlockTags = map[string]string{
+	"if":       "endif",
+	"unless":   "endunless",
+	"case":     "endcase",
+	"for":      "endfor",
+	"tablerow": "endtablerow",
+	"capture":  "endcapture",
+}
+
+// liquidBranchTags maps tags which split a block to the blocks they may appear in
+var liquidBranchTags = map[string][]string{
+	"elsif": {"if", "unless"},
+	"else":  {"if", "unless", "case", "for"},
+	"when":  {"case"},
+}
+
+// liquidKeywords are words of Liquid expressions which aren't placeholders
+var liquidKeywords = []string{"and", "or", "contains", "in", "true", "false", "nil", "null", "empty", "blank", "reversed", "limit", "offset", "cols"}
+
+var (
+	liquidFilterPattern = regexp.MustCompile(`^[a-z_]+$`)
+	liquidTokenPattern  = regexp.MustCompile(`'[^']*'?|"[^"]*"?|[A-Za-z_][\w\-]*(\.[\w\-]+|\[[^\]]*\])*|\S`)
+	liquidIndexPattern  = regexp.MustCompile(`\[[^\]]*\]`)
+
+	// liquidEndPatterns match the tags closing raw and comment, whose content isn't parsed
+	liquidEndPatterns = map[string]*regexp.Regexp{
+		"raw":     regexp.MustCompile(`\{%-?\s*endraw\s*-?%\}`),
+		"comment": regexp.MustCompile(`\{%-?\s*endcomment\s*-?%\}`),
+	}
+
+	// liquidPlaceholderPatterns match placeholders of custom fields and dynamic content
+	liquidPlaceholderPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^ticket\.ticket_field(_option_title)?_\d+$`),
+		regexp.MustCompile(`^(ticket\.(requester|assignee)|current_user)(\.organization)?\.custom_fields\.[\w\-]+$`),
+		regexp.MustCompile(`^ticket\.organization\.custom_fields\.[\w\-]+$`),
+		regexp.MustCompile(`^dc\.[\w\-]+$`),
+	}
+)
+
+// liquidPlaceholders lists the documented Zendesk Support placeholders.
+// ref: https://support.zendesk.com/hc/en-us/articles/4408886858138
+var liquidPlaceholders = func() map[string]bool {
+	organization := []string{"name", "details", "notes", "external_id", "tags"}
+	user := []string{"id", "name", "first_name", "last_name", "email", "phone", "language", "locale", "details", "notes", "external_id", "signature", "tags", "time_zone", "role"}
+	satisfaction := []string{"rating_section", "current_rating", "current_comment", "positive_rating_url", "negative_rating_url", "rating_url"}
+	ticket := []string{
+		"id", "encoded_id", "title", "description", "verbatim_description", "url", "url_with_protocol", "link",
+		"external_id", "via", "status", "priority", "ticket_type", "score", "account", "ticket_form",
+		"brand.name", "group.name", "in_business_hours", "current_holiday_name",
+		"due_date", "due_date_with_timestamp", "created_at", "created_at_with_timestamp", "created_at_with_time",
+		"updated_at", "updated_at_with_timestamp", "updated_at_with_time",
+		"tags", "cc_names", "ccs", "email_cc_names", "follower_names",
+		"comments", "comments_formatted", "public_comments", "public_comments_formatted",
+		"latest_comment", "latest_comment_formatted", "latest_comment_html", "latest_comment_rich",
+		"latest_public_comment", "latest_public_comment_formatted", "latest_public_comment_html", "latest_public_comment_rich",
+	}
+
+	placeholders := map[string]bool{}
+	add := func(prefix string, names []string) {
+		for _, name := range names {
+			placeholders[prefix+name] = true
+		}
+	}
+
+	add("ticket.", ticket)
+	add("ticket.organization.", organization)
+	add("ticket.satisfaction.", satisfaction)
+	add("satisfaction.", satisfaction)
+	for _, u := range []string{"ticket.requester.", "ticket.assignee.", "current_user."} {
+		add(u, user)
+		add(u+"organization.", organization)
+	}
+
+	return placeholders
+}()
+
+// isLiquidPlaceholder reports whether path names a known placeholder. Collections
+// may be followed by first, last or size, and elements of them aren't checked.
+func isLiquidPlaceholder(path string) bool {
+	if i := strings.Index(path, "["); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, ".")
+	for i, s := range segments {
+		if i > 0 && (s == "first" || s == "last" || s == "size") {
+			path = strings.Join(segments[:i], ".")
+			break
+		}
+	}
+
+	if liquidPlaceholders[path] {
+		return true
+	}
+	for _, p := range liquidPlaceholderPatterns {
+		if p.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// liquidLinter collects problems while walking the tags of a text
+type liquidLinter struct {
+	text     string
+	problems []string
+	blocks   []string
+	// locals holds variables defined by assign, capture and for tags
+	locals map[string]bool
+}
+
+// lintLiquid checks the syntax and placeholder names of the Liquid tags in text.
+// Text without tags has no problems.
+func lintLiquid(text string) []string {
+	l := &liquidLinter{
+		text:   text,
+		locals: map[string]bool{"forloop": true, "tablerowloop": true},
+	}
+
+	for i := 0; i+1 < len(text); i++ {
+		if text[i] != '{' || (text[i+1] != '{' && text[i+1] != '%') {
+			continue
+		}
+
+		closing := "}}"
+		if text[i+1] == '%' {
+			closing = "%}"
+		}
+
+		end := strings.Index(text[i+2:], closing)
+		if end < 0 {
+			l.addf(i, "%s is never closed with %s", text[i:i+2], closing)
+			break
+		}
+
+		tag := text[i : i+2+end+2]
+		inner := strings.TrimSpace(strings.Trim(text[i+2:i+2+end], "-"))
+		next := i + 2 + end + 2
+
+		if closing == "}}" {
+			l.output(i, tag, inner)
+		} else {
+			next = l.tag(i, tag, inner, next)
+		}
+		i = next - 1
+	}
+
+	for _, block := range l.blocks {
+		l.addf(len(text), "{%% %s %%} is never closed with {%% %s %%}", block, liquidBlockTags[block])
+	}
+
+	return l.problems
+}
+
+func (l *liquidLinter) addf(offset int, format string, a ...interface{}) {
+	line := strings.Count(l.text[:offset], "\n") + 1
+	l.problems = append(l.problems, fmt.Sprintf("line %d: %s", line, fmt.Sprintf(format, a...)))
+}
+
+// output checks an output tag such as {{ticket.title | upcase}}
+func (l *liquidLinter) output(offset int, tag, inner string) {
+	if inner == "" {
+		l.addf(offset, "%s is empty", tag)
+		return
+	}
+	if strings.Contains(inner, "{{") || strings.Contains(inner, "{%") {
+		l.addf(offset, "%s contains another tag, a preceding tag is probably not closed", tag)
+		return
+	}
+
+	parts := strings.Split(inner, "|")
+	l.expression(offset, tag, parts[0])
+	for _, filter := range parts[1:] {
+		name, _, _ := strings.Cut(filter, ":")
+		if name = strings.TrimSpace(name); !liquidFilterPattern.MatchString(name) {
+			l.addf(offset, "%s has an invalid filter %q", tag, name)
+		}
+	}
+}
+
+// tag checks a tag such as {% if ticket.status == "open" %} and returns the offset to continue at
+func (l *liquidLinter) tag(offset int, tag, inner string, next int) int {
+	name, args, _ := strings.Cut(inner, " ")
+	args = strings.TrimSpace(args)
+
+	switch {
+	case name == "":
+		l.addf(offset, "%s is empty", tag)
+	case liquidEndPatterns[name] != nil:
+		end := liquidEndPatterns[name].FindStringIndex(l.text[next:])
+		if end == nil {
+			l.addf(offset, "%s is never closed with {%% end%s %%}", tag, name)
+			return len(l.text)
+		}
+		return next + end[1]
+	case liquidBlockTags[name] != "":
+		l.blocks = append(l.blocks, name)
+		switch name {
+		case "for", "tablerow":
+			fields := strings.Fields(args)
+			if len(fields) < 3 || fields[1] != "in" {
+				l.addf(offset, "%s should be written as {%% %s <variable> in <collection> %%}", tag, name)
+				break
+			}
+			l.locals[fields[0]] = true
+			l.expression(offset, tag, strings.Join(fields[2:], " "))
+		case "capture":
+			l.locals[args] = true
+		default:
+			l.expression(offset, tag, args)
+		}
+	case strings.HasPrefix(name, "end"):
+		if len(l.blocks) == 0 {
+			l.addf(offset, "%s doesn't close any block", tag)
+			break
+		}
+		open := l.blocks[len(l.blocks)-1]
+		l.blocks = l.blocks[:len(l.blocks)-1]
+		if liquidBlockTags[open] != name {
+			l.addf(offset, "%s closes {%% %s %%}, which should be closed with {%% %s %%}", tag, open, liquidBlockTags[open])
+		}
+	case liquidBranchTags[name] != nil:
+		if len(l.blocks) == 0 || !containsString(liquidBranchTags[name], l.blocks[len(l.blocks)-1]) {
+			l.addf(offset, "%s can only be used inside {%% %s %%}", tag, strings.Join(liquidBranchTags[name], " %} or {% "))
+		}
+		l.expression(offset, tag, args)
+	case name == "assign":
+		variable, value, found := strings.Cut(args, "=")
+		if !found || strings.TrimSpace(variable) == "" {
+			l.addf(offset, "%s should be written as {%% assign <variable> = <value> %%}", tag)
+			break
+		}
+		l.locals[strings.TrimSpace(variable)] = true
+		value, _, _ = strings.Cut(value, "|")
+		l.expression(offset, tag, value)
+	case name == "increment" || name == "decrement":
+		l.locals[args] = true
+	case name == "echo":
+		l.output(offset, tag, args)
+	case name == "cycle" || name == "break" || name == "continue":
+	default:
+		l.addf(offset, "%s uses the unknown tag %q", tag, name)
+	}
+
+	return next
+}
+
+// expression checks the strings and placeholders of a Liquid expression
+func (l *liquidLinter) expression(offset int, tag, expr string) {
+	for _, token := range liquidTokenPattern.FindAllString(expr, -1) {
+		switch {
+		case token[0] == '\'' || token[0] == '"':
+			if len(token) < 2 || token[len(token)-1] != token[0] {
+				l.addf(offset, "%s has an unterminated string", tag)
+			}
+		case token[0] == '_' || (token[0] >= 'A' && token[0] <= 'Z') || (token[0] >= 'a' && token[0] <= 'z'):
+			root, _, _ := strings.Cut(liquidIndexPattern.ReplaceAllString(token, ""), ".")
+			if containsString(liquidKeywords, token) || l.locals[root] {
+				continue
+			}
+			if !isLiquidPlaceholder(token) {
+				l.addf(offset, "%s uses the unknown placeholder %q", tag, token)
+			}
+		}
+	}
+}
+
+// validateLiquid warns about problems found by lintLiquid. Providers configured
+// with strict_liquid report them as errors during plan with lintLiquidDiff.
+func validateLiquid() schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		text, ok := i.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Expected type to be string",
+				AttributePath: path,
+			}}
+		}
+
+		var diags diag.Diagnostics
+		for _, problem := range lintLiquid(text) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "Possible Liquid placeholder problem",
+				Detail:        problem,
+				AttributePath: path,
+			})
+		}
+		return diags
+	}
+}
+
+// lintLiquidDiff fails the plan when the text in the given attributes has Liquid
+// problems and the provider is configured with strict_liquid
+func lintLiquidDiff(keys ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		m, ok := meta.(*providerMeta)
+		if !ok || !m.strictLiquid {
+			return nil
+		}
+
+		for _, key := range keys {
+			for _, text := range liquidStrings(d.Get(key)) {
+				if problems := lintLiquid(text); len(problems) > 0 {
+					return fmt.Errorf("invalid Liquid in %s: %s", key, strings.Join(problems, "; "))
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// liquidStrings returns every string nested in an attribute value
+func liquidStrings(v interface{}) []string {
+	var texts []string
+	switch value := v.(type) {
+	case string:
+		texts = append(texts, value)
+	case *schema.Set:
+		texts = append(texts, liquidStrings(value.List())...)
+	case []interface{}:
+		for _, e := range value {
+			texts = append(texts, liquidStrings(e)...)
+		}
+	case map[string]interface{}:
+		for _, e := range value {
+			texts = append(texts, liquidStrings(e)...)
+		}
+	}
+	return texts
+}
@@ -0,0 +1,58 @@
+package zendesk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestLintLiquid(t *testing.T) {
+	valid := []string{
+		"solved",
+		`{"text":{"nested":{"a":1}}}`,
+		"Hi {{ticket.requester.first_name}}, your request #{{ticket.id}} was updated.",
+		"{{ ticket.title | upcase | truncate: 20 }}",
+		"{{ticket.ticket_field_360000000001}} {{ticket.requester.custom_fields.account_tier}} {{dc.welcome}}",
+		`{% if ticket.status == "Open" and ticket.priority != 'Low' %}open{% elsif ticket.tags contains "vip" %}vip{% else %}other{% endif %}`,
+		"{% for comment in ticket.comments limit:2 %}{{comment.author.name}} {{forloop.index}}{% endfor %}",
+		"{% assign tier = ticket.organization.custom_fields.tier | downcase %}{% case tier %}{% when 'gold' %}{{tier}}{% endcase %}",
+		"{% raw %}{{ not parsed {% endraw %}{{ticket.comments.first.value}}",
+		"{%- unless ticket.in_business_hours -%}closed{%- endunless -%}",
+	}
+	for _, text := range valid {
+		if problems := lintLiquid(text); len(problems) != 0 {
+			t.Fatalf("lintLiquid(%q) returned problems: %v", text, problems)
+		}
+	}
+
+	invalid := map[string]string{
+		"Hi {{ticket.requester.first_name}":          "never closed",
+		"{{ticket.idd}}":                             `unknown placeholder "ticket.idd"`,
+		"{{}}":                                       "is empty",
+		"{% if ticket.status == 'open' %}open":       "{% if %} is never closed with {% endif %}",
+		"{% if ticket.id %}{% endfor %}":             "should be closed with {% endif %}",
+		"{% else %}":                                 "can only be used inside",
+		"{% when 'a' %}":                             "can only be used inside {% case %}",
+		"{% iff ticket.id %}{% endif %}":             `unknown tag "iff"`,
+		"{{ ticket.title | up-case }}":               "invalid filter",
+		`{% if ticket.status == "open %}{% endif %}`: "unterminated string",
+		"{% for ticket.comments %}{% endfor %}":      "should be written as",
+		"line one\n{{ current_user.nam }}":           "line 2:",
+		"{{ticket.title {{ticket.id}}":               "contains another tag",
+	}
+	for text, expected := range invalid {
+		problems := lintLiquid(text)
+		if !strings.Contains(strings.Join(problems, "\n"), expected) {
+			t.Fatalf("lintLiquid(%q) returned %v. should have reported %q", text, problems, expected)
+		}
+	}
+}
+
+func TestValidateLiquid(t *testing.T) {
+	diags := validateLiquid()("Hi {{ticket.requster.name}}", cty.GetAttrPath("value"))
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("validateLiquid returned %v. should have returned one warning", diags)
+	}
+}
@@ -1,32 +1,35 @@
 package models
 
 type TicketForm struct {
-	ID                 int64            `json:"id,omitempty"`
-	URL                string           `json:"url,omitempty"`
-	Name               string           `json:"name"`
-	RawName            string           `json:"raw_name,omitempty"`
-	DisplayName        string           `json:"display_name,omitempty"`
-	RawDisplayName     string           `json:"raw_display_name,omitempty"`
-	Position           int64            `json:"position"`
-	Active             bool             `json:"active,omitempty"`
-	EndUserVisible     bool             `json:"end_user_visible,omitempty"`
-	Default            bool             `json:"default,omitempty"`
-	TicketFieldIDs     []int64          `json:"ticket_field_ids,omitempty"`
-	InAllBrands        bool             `json:"in_all_brands,omitempty"`
-	RestrictedBrandIDs []int64          `json:"restricted_brand_ids,omitempty"`
-	AgentConditions    []AgentCondition `json:"agent_conditions,omitempty"`
+	ID                 int64                 `json:"id,omitempty"`
+	URL                string                `json:"url,omitempty"`
+	Name               string                `json:"name"`
+	RawName            string                `json:"raw_name,omitempty"`
+	DisplayName        string                `json:"display_name,omitempty"`
+	RawDisplayName     string                `json:"raw_display_name,omitempty"`
+	Position           int64                 `json:"position"`
+	Active             bool                  `json:"active,omitempty"`
+	EndUserVisible     bool                  `json:"end_user_visible,omitempty"`
+	Default            bool                  `json:"default,omitempty"`
+	TicketFieldIDs     []int64               `json:"ticket_field_ids,omitempty"`
+	InAllBrands        bool                  `json:"in_all_brands,omitempty"`
+	RestrictedBrandIDs []int64               `json:"restricted_brand_ids,omitempty"`
+	AgentConditions    []TicketFormCondition `json:"agent_conditions"`
+	EndUserConditions  []TicketFormCondition `json:"end_user_conditions"`
 }
 
-type AgentCondition struct {
+// TicketFormCondition shows child fields of a ticket form when the parent field has a value.
+// End user conditions don't have RequiredOnStatuses.
+type TicketFormCondition struct {
 	ParentFieldId int64         `json:"parent_field_id"`
 	Value         string        `json:"value"`
 	ChildFields   []ChildFields `json:"child_fields"` // eg, matching_value, matching_value_1
 }
 
 type ChildFields struct {
-	Id                 int64              `json:"id"`
-	IsRequired         bool               `json:"is_required"`
-	RequiredOnStatuses RequiredOnStatuses `json:"required_on_statuses"`
+	Id                 int64               `json:"id"`
+	IsRequired         bool                `json:"is_required"`
+	RequiredOnStatuses *RequiredOnStatuses `json:"required_on_statuses,omitempty"`
 }
 
 type RequiredOnStatuses struct {
@@ -2,6 +2,11 @@ package zendesk
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -11,14 +16,40 @@ import (
 )
 
 const (
-	accountVar = "ZENDESK_ACCOUNT"
-	emailVar   = "ZENDESK_EMAIL"
-	tokenVar   = "ZENDESK_TOKEN"
+	accountVar      = "ZENDESK_ACCOUNT"
+	emailVar        = "ZENDESK_EMAIL"
+	tokenVar        = "ZENDESK_TOKEN"
+	oauthTokenVar   = "ZENDESK_OAUTH_TOKEN"
+	clientIDVar     = "ZENDESK_CLIENT_ID"
+	clientSecretVar = "ZENDESK_CLIENT_SECRET"
+	apiURLVar       = "ZENDESK_API_URL"
+	proxyURLVar     = "ZENDESK_PROXY_URL"
+	caBundleVar     = "ZENDESK_CA_BUNDLE"
+
+	providerName = "terraform-provider-zendesk"
 )
 
+// testTransport wraps the transport of every client when set. Only tests set
+// it, e.g. to record or replay API traffic.
+var testTransport func(base http.RoundTripper) (http.RoundTripper, error)
+
+// accountNamePattern restricts names of additional accounts, so they can prefix import IDs
+var accountNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 // Provider returns provider instance for Zendesk
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	return newProvider("dev")
+}
+
+// New returns a factory of provider instances which report version in their User-Agent
+func New(version string) func() *schema.Provider {
+	return func() *schema.Provider {
+		return newProvider(version)
+	}
+}
+
+func newProvider(version string) *schema.Provider {
+	p := &schema.Provider{
 		// https://developer.zendesk.com/rest_api/docs/support/introduction#security-and-authentication
 		Schema: map[string]*schema.Schema{
 			"account": {
@@ -43,67 +74,318 @@ func Provider() *schema.Provider {
 				Sensitive:    true,
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
+			"oauth_token": {
+				Description:  "[OAuth access token](https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_tokens/) used as a bearer token. Conflicts with `email`/`token` and `client_id`/`client_secret`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc(oauthTokenVar, ""),
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"client_id": {
+				Description:  "Unique identifier of an OAuth client. Used with `client_secret` to request an access token with the client credentials grant.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc(clientIDVar, ""),
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"client_secret": {
+				Description:  "Secret of the OAuth client identified by `client_id`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc(clientSecretVar, ""),
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"api_url": {
+				Description:  "Base URL of the Zendesk API, e.g. `https://example.zendesk.com/api/v2`. Overrides the URL derived from `account`, which is useful for sandbox host mappings and local API stand-ins.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc(apiURLVar, ""),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"proxy_url": {
+				Description:  "URL of the HTTP proxy used for API requests. Defaults to the `HTTPS_PROXY`/`HTTP_PROXY` environment variables.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc(proxyURLVar, ""),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"ca_bundle_file": {
+				Description:      "Path to a PEM encoded CA bundle which is trusted in addition to the system certificate pool.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc(caBundleVar, ""),
+				ValidateDiagFunc: isValidFile(),
+			},
+			"user_agent_suffix": {
+				Description:  "Text appended to the User-Agent header of every API request, e.g. the name of the pipeline running Terraform.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"max_retries": {
+				Description:  "Maximum number of times a request is retried when Zendesk responds with 429 Too Many Requests or a 5xx error. Requests which create objects are only retried on 429 and on 503 with `Retry-After`, so that they aren't created twice. Set to 0 to disable retries.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      newClient.DefaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Description:  "Maximum number of seconds to wait between two attempts of a request. Exponential backoff is capped at this value, and requests whose `Retry-After` is longer fail instead of being retried.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(newClient.DefaultRetryMaxWait / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"accounts": {
+				Description: "Additional Zendesk instances, e.g. a sandbox, which resources and data sources select with their `account` attribute. Proxy, CA bundle, retry and User-Agent settings are shared with the top-level account.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description:  "Name that resources use to refer to this account.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(accountNamePattern, "must only contain letters, digits, underscores and hyphens"),
+						},
+						"account": {
+							Description:  "Account name of the Zendesk instance.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"email": {
+							Description:  "Email address of agent user who have permission to access the API.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"token": {
+							Description:  "API token for the Zendesk instance.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"oauth_token": {
+							Description:  "OAuth access token used as a bearer token.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"client_id": {
+							Description:  "Unique identifier of an OAuth client, used with `client_secret`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"client_secret": {
+							Description:  "Secret of the OAuth client identified by `client_id`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"api_url": {
+							Description:  "Base URL of the Zendesk API. Overrides the URL derived from `account`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+					},
+				},
+			},
+			"strict_liquid": {
+				Description: "Fail plans when the Liquid placeholders in triggers, automations, macros and dynamic content variants have problems, such as unclosed tags or unknown placeholder names. By default these problems are reported as warnings.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"zendesk_automation":                resourceZendeskAutomation(),
-			"zendesk_brand":                     resourceZendeskBrand(),
-			"zendesk_dynamic_content":           resourceZendeskDynamicContent(),
-			"zendesk_dynamic_content_variant":   resourceZendeskDynamicContentVariant(),
-			"zendesk_group":                     resourceZendeskGroup(),
-			"zendesk_ticket_field":              resourceZendeskTicketField(),
-			"zendesk_macro":                     resourceZendeskMacro(),
-			"zendesk_view":                      resourceZendeskView(),
-			"zendesk_user_field":                resourceZendeskUserField(),
-			"zendesk_ticket_form":               resourceZendeskTicketForm(),
-			"zendesk_trigger":                   resourceZendeskTrigger(),
-			"zendesk_trigger_category":           resourceZendeskTriggerCategory(),
-			"zendesk_target":                    resourceZendeskTarget(),
-			"zendesk_attachment":                resourceZendeskAttachment(),
-			"zendesk_organization":              resourceZendeskOrganization(),
-			"zendesk_organization_field":        resourceZendeskOrganizationField(),
-			"zendesk_sla_policy":                resourceZendeskSLAPolicy(),
-			"zendesk_webhook":                   resourceZendeskWebhook(),
-			"zendesk_custom_roles":              resourceZendeskCustomRoles(),
-			"zendesk_custom_statuses":           resourceZendeskCustomStatuses(),
-			"zendesk_group_memberships":          resourceZendeskGroupMemberships(),
+			"zendesk_automation":               resourceZendeskAutomation(),
+			"zendesk_brand":                    resourceZendeskBrand(),
+			"zendesk_dynamic_content":          resourceZendeskDynamicContent(),
+			"zendesk_dynamic_content_variant":  resourceZendeskDynamicContentVariant(),
+			"zendesk_group":                    resourceZendeskGroup(),
+			"zendesk_ticket_field":             resourceZendeskTicketField(),
+			"zendesk_macro":                    resourceZendeskMacro(),
+			"zendesk_view":                     resourceZendeskView(),
+			"zendesk_user_field":               resourceZendeskUserField(),
+			"zendesk_ticket_form":              resourceZendeskTicketForm(),
+			"zendesk_trigger":                  resourceZendeskTrigger(),
+			"zendesk_trigger_category":         resourceZendeskTriggerCategory(),
+			"zendesk_target":                   resourceZendeskTarget(),
+			"zendesk_attachment":               resourceZendeskAttachment(),
+			"zendesk_organization":             resourceZendeskOrganization(),
+			"zendesk_organization_field":       resourceZendeskOrganizationField(),
+			"zendesk_sla_policy":               resourceZendeskSLAPolicy(),
+			"zendesk_webhook":                  resourceZendeskWebhook(),
+			"zendesk_custom_roles":             resourceZendeskCustomRoles(),
+			"zendesk_custom_statuses":          resourceZendeskCustomStatuses(),
+			"zendesk_group_memberships":        resourceZendeskGroupMemberships(),
 			"zendesk_organization_memberships": resourceZendeskOrganizationMemberships(),
-			"zendesk_users":                     resourceZendeskUsers(),
-			"zendesk_tickets":                   resourceZendeskTickets(),
-			"zendesk_queues":                    resourceZendeskQueues(),
+			"zendesk_users":                    resourceZendeskUsers(),
+			"zendesk_tickets":                  resourceZendeskTickets(),
+			"zendesk_queues":                   resourceZendeskQueues(),
+			"zendesk_oauth_client":             resourceZendeskOAuthClient(),
+			"zendesk_oauth_token":              resourceZendeskOAuthToken(),
+			"zendesk_trigger_order":            resourceZendeskOrder(newClient.TriggerCollection, "triggers"),
+			"zendesk_automation_order":         resourceZendeskOrder(newClient.AutomationCollection, "automations"),
+			"zendesk_macro_order":              resourceZendeskOrder(newClient.MacroCollection, "macros"),
+			"zendesk_view_order":               resourceZendeskOrder(newClient.ViewCollection, "views"),
+			"zendesk_sla_policy_order":         resourceZendeskOrder(newClient.SLAPolicyCollection, "SLA policies"),
+			"zendesk_ticket_form_order":        resourceZendeskOrder(newClient.TicketFormCollection, "ticket forms"),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"zendesk_ticket_field":          dataSourceZendeskTicketField(),
-			"zendesk_webhook":               dataSourceZendeskWebhook(),
-			"zendesk_tags":                  dataSourceZendeskTags(),
-			"zendesk_locales":               dataSourceZendeskLocales(),
+			"zendesk_ticket_field":         dataSourceZendeskTicketField(),
+			"zendesk_webhook":              dataSourceZendeskWebhook(),
+			"zendesk_tags":                 dataSourceZendeskTags(),
+			"zendesk_locales":              dataSourceZendeskLocales(),
 			"zendesk_satisfaction_ratings": dataSourceZendeskSatisfactionRatings(),
+			"zendesk_oauth_clients":        dataSourceZendeskOAuthClients(),
+			"zendesk_trigger":              dataSourceZendeskTrigger(),
+			"zendesk_triggers":             dataSourceZendeskTriggers(),
+			"zendesk_group":                dataSourceZendeskGroup(),
+			"zendesk_groups":               dataSourceZendeskGroups(),
 		},
+	}
 
-		ConfigureContextFunc: providerConfigure,
+	for _, r := range p.ResourcesMap {
+		withAccount(r, false)
 	}
+	for _, r := range p.DataSourcesMap {
+		withAccount(r, true)
+	}
+
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return providerConfigure(ctx, d, p.UserAgent(providerName, version))
+	}
+
+	return p
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func providerConfigure(ctx context.Context, d *schema.ResourceData, userAgent string) (interface{}, diag.Diagnostics) {
+	if v, ok := d.GetOk("user_agent_suffix"); ok {
+		userAgent = userAgent + " " + strings.TrimSpace(v.(string))
+	}
 
 	config := Config{
-		Account: d.Get("account").(string),
-		Email:   d.Get("email").(string),
-		Token:   d.Get("token").(string),
+		Account:      d.Get("account").(string),
+		Email:        d.Get("email").(string),
+		Token:        d.Get("token").(string),
+		OAuthToken:   d.Get("oauth_token").(string),
+		ClientID:     d.Get("client_id").(string),
+		ClientSecret: d.Get("client_secret").(string),
+		APIURL:       d.Get("api_url").(string),
+		ProxyURL:     d.Get("proxy_url").(string),
+		CABundleFile: d.Get("ca_bundle_file").(string),
+		MaxRetries:   d.Get("max_retries").(int),
+		RetryMaxWait: time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+		StrictLiquid: d.Get("strict_liquid").(bool),
+	}
+
+	zd, diags := newZendeskClient(ctx, config, userAgent)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	zd.Accounts = make(map[string]*newClient.Client)
+	for _, v := range d.Get("accounts").([]interface{}) {
+		entry := v.(map[string]interface{})
+		name := entry["name"].(string)
+		if _, ok := zd.Accounts[name]; ok {
+			return nil, diag.Errorf("account %q is configured more than once in accounts", name)
+		}
+
+		// network and retry settings are shared with the top-level account
+		accountConfig := config
+		accountConfig.Account = entry["account"].(string)
+		accountConfig.Email = entry["email"].(string)
+		accountConfig.Token = entry["token"].(string)
+		accountConfig.OAuthToken = entry["oauth_token"].(string)
+		accountConfig.ClientID = entry["client_id"].(string)
+		accountConfig.ClientSecret = entry["client_secret"].(string)
+		accountConfig.APIURL = entry["api_url"].(string)
+
+		accountZd, accountDiags := newZendeskClient(ctx, accountConfig, userAgent)
+		for i := range accountDiags {
+			accountDiags[i].Summary = fmt.Sprintf("%s (account %q)", accountDiags[i].Summary, name)
+		}
+		diags = append(diags, accountDiags...)
+		if accountDiags.HasError() {
+			return nil, diags
+		}
+
+		zd.Accounts[name] = accountZd
+	}
+
+	return &providerMeta{
+		Client:       zd,
+		strictLiquid: config.StrictLiquid,
+	}, diags
+}
+
+// newZendeskClient creates an API client authenticated as configured in config
+func newZendeskClient(ctx context.Context, config Config, userAgent string) (*newClient.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	diags = append(diags, config.validate()...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	transport, err := config.transport()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var base http.RoundTripper = transport
+	if testTransport != nil {
+		base, err = testTransport(transport)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+
+	httpClient := &http.Client{
+		Transport: newClient.NewRetryTransport(newClient.NewLoggingTransport(base), config.MaxRetries, config.RetryMaxWait),
 	}
 
 	// Create & configure Zendesk API client
-	zd, err := client.NewClient(nil) // TODO: set UserAgent to terraform/version
+	zd, err := client.NewClient(httpClient)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
-	if err = zd.SetSubdomain(config.Account); err != nil {
+	zd.SetHeader("User-Agent", userAgent)
+
+	if config.APIURL != "" {
+		err = zd.SetEndpointURL(strings.TrimSuffix(config.APIURL, "/"))
+	} else {
+		err = zd.SetSubdomain(config.Account)
+	}
+	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	zd.SetCredential(client.NewAPITokenCredential(config.Email, config.Token))
+
+	switch {
+	case config.OAuthToken != "":
+		zd.SetCredential(client.NewBearerTokenCredential(config.OAuthToken))
+	case config.ClientID != "":
+		token, err := newClient.RequestClientCredentialsToken(ctx, httpClient, config.oauthTokenURL(), config.ClientID, config.ClientSecret)
+		if err != nil {
+			return nil, diag.Errorf("could not obtain OAuth access token for client %s: %s", config.ClientID, err)
+		}
+		zd.SetCredential(client.NewBearerTokenCredential(token.AccessToken))
+	default:
+		zd.SetCredential(client.NewAPITokenCredential(config.Email, config.Token))
+	}
 
 	newZd := &newClient.Client{
 		Client: *zd,
@@ -1,10 +1,20 @@
 package zendesk
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/nukosuke/terraform-provider-zendesk/internal/fakezendesk"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
 )
 
 var testAccProviders map[string]*schema.Provider
@@ -17,7 +27,22 @@ func init() {
 	}
 }
 
+// testAccFakeServer is the fake Zendesk API of the running acceptance test,
+// or nil when the test runs against a real account
+var testAccFakeServer *fakezendesk.Server
+
+// testAccPreCheck points the provider at a fake Zendesk API unless an account
+// or API URL is set, so that acceptance tests run without a Zendesk account
 func testAccPreCheck(t *testing.T) {
+	testAccFakeServer = nil
+	if os.Getenv(accountVar) == "" && os.Getenv(apiURLVar) == "" {
+		testAccFakeServer = fakezendesk.NewServer()
+		t.Cleanup(testAccFakeServer.Close)
+		t.Setenv(apiURLVar, testAccFakeServer.URL+"/api/v2")
+		t.Setenv(oauthTokenVar, "fake")
+		return
+	}
+
 	if v := os.Getenv(emailVar); v == "" {
 		t.Fatalf("%s must be set for acceptance tests", emailVar)
 	}
@@ -34,3 +59,465 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  Config
+		isValid bool
+	}{
+		{"api token", Config{Account: "example", Email: "john.doe@example.com", Token: "xxx"}, true},
+		{"oauth token", Config{Account: "example", OAuthToken: "xxx"}, true},
+		{"client credentials", Config{Account: "example", ClientID: "terraform", ClientSecret: "xxx"}, true},
+		{"missing account", Config{OAuthToken: "xxx"}, false},
+		{"missing credentials", Config{Account: "example"}, false},
+		{"email without token", Config{Account: "example", Email: "john.doe@example.com"}, false},
+		{"client id without secret", Config{Account: "example", ClientID: "terraform"}, false},
+		{"api token and oauth token", Config{Account: "example", Email: "john.doe@example.com", Token: "xxx", OAuthToken: "xxx"}, false},
+		{"oauth token and client credentials", Config{Account: "example", OAuthToken: "xxx", ClientID: "terraform", ClientSecret: "xxx"}, false},
+	}
+
+	for _, c := range cases {
+		diags := c.config.validate()
+		if c.isValid && diags.HasError() {
+			t.Fatalf("%s: expected config to be valid but got %v", c.name, diags)
+		}
+		if !c.isValid && !diags.HasError() {
+			t.Fatalf("%s: expected config to be invalid", c.name)
+		}
+	}
+}
+
+func TestRequestClientCredentialsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if payload["grant_type"] != "client_credentials" || payload["client_id"] != "terraform" || payload["client_secret"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+
+		w.Write([]byte(`{"access_token":"abc123","token_type":"bearer","scope":"read write"}`))
+	}))
+	defer server.Close()
+
+	token, err := newClient.RequestClientCredentialsToken(context.Background(), server.Client(), server.URL, "terraform", "secret")
+	if err != nil {
+		t.Fatalf("RequestClientCredentialsToken returned an error: %v", err)
+	}
+	if token.AccessToken != "abc123" {
+		t.Fatalf("access token was %s. should have been abc123", token.AccessToken)
+	}
+
+	_, err = newClient.RequestClientCredentialsToken(context.Background(), server.Client(), server.URL, "terraform", "wrong")
+	if err == nil {
+		t.Fatal("RequestClientCredentialsToken did not return an error for invalid credentials")
+	}
+}
+
+func TestProviderConfigureAPIURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/triggers/1.json" || r.Header.Get("Authorization") != "Bearer xxx" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("User-Agent") != "terraform-provider-zendesk/test ci-pipeline" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"trigger":{"id":1,"title":"Auto reply"}}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_url":           server.URL + "/api/v2",
+		"oauth_token":       "xxx",
+		"user_agent_suffix": "ci-pipeline",
+	})
+
+	meta, diags := providerConfigure(context.Background(), d, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("providerConfigure returned an error: %v", diags)
+	}
+
+	trigger, err := meta.(*providerMeta).GetTrigger(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetTrigger against api_url returned an error: %v", err)
+	}
+	if trigger.Title != "Auto reply" {
+		t.Fatalf("trigger had title %s. should have been Auto reply", trigger.Title)
+	}
+}
+
+func TestProviderConfigureAccounts(t *testing.T) {
+	newServer := func(title string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"trigger":{"id":1,"title":"` + title + `"}}`))
+		}))
+	}
+	prod := newServer("Prod trigger")
+	defer prod.Close()
+	sandbox := newServer("Sandbox trigger")
+	defer sandbox.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_url":     prod.URL + "/api/v2",
+		"oauth_token": "xxx",
+		"accounts": []interface{}{
+			map[string]interface{}{
+				"name":        "sandbox",
+				"api_url":     sandbox.URL + "/api/v2",
+				"oauth_token": "yyy",
+			},
+		},
+	})
+
+	meta, diags := providerConfigure(context.Background(), d, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("providerConfigure returned an error: %v", diags)
+	}
+
+	r := resourceZendeskTrigger()
+	withAccount(r, false)
+
+	for account, expected := range map[string]string{"": "Prod trigger", "sandbox": "Sandbox trigger"} {
+		rd := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"account": account,
+		})
+		rd.SetId("1")
+
+		if diags := r.ReadContext(context.Background(), rd, meta); diags.HasError() {
+			t.Fatalf("read in account %q returned an error: %v", account, diags)
+		}
+		if v := rd.Get("title"); v != expected {
+			t.Fatalf("trigger read in account %q had title %v. should have been %s", account, v, expected)
+		}
+	}
+
+	rd := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account": "eu",
+	})
+	rd.SetId("1")
+	if diags := r.ReadContext(context.Background(), rd, meta); !diags.HasError() {
+		t.Fatal("read in an unknown account should have returned an error")
+	}
+}
+
+func TestProviderConfigureDuplicateAccounts(t *testing.T) {
+	account := map[string]interface{}{
+		"name":        "sandbox",
+		"api_url":     "https://example.zendesk.com/api/v2",
+		"oauth_token": "yyy",
+	}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_url":     "https://example.zendesk.com/api/v2",
+		"oauth_token": "xxx",
+		"accounts":    []interface{}{account, account},
+	})
+
+	if _, diags := providerConfigure(context.Background(), d, "terraform-provider-zendesk/test"); !diags.HasError() {
+		t.Fatal("providerConfigure should have rejected duplicate account names")
+	}
+}
+
+func TestProviderConfigureStrictLiquid(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_url":       "https://example.zendesk.com/api/v2",
+		"oauth_token":   "xxx",
+		"strict_liquid": true,
+		"accounts": []interface{}{
+			map[string]interface{}{
+				"name":        "sandbox",
+				"api_url":     "https://example-sandbox.zendesk.com/api/v2",
+				"oauth_token": "yyy",
+			},
+		},
+	})
+
+	meta, diags := providerConfigure(context.Background(), d, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("providerConfigure returned an error: %v", diags)
+	}
+
+	r := resourceZendeskDynamicContentVariant()
+	withAccount(r, false)
+
+	for _, account := range []string{"", "sandbox"} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"account":                 account,
+			"dynamic_content_item_id": 1,
+			"locale_id":               1,
+			"default":                 true,
+			"content":                 "Hi {{ticket.requester.name",
+		})
+		_, err := r.Diff(context.Background(), nil, config, meta)
+		if err == nil || !strings.Contains(err.Error(), "invalid Liquid") {
+			t.Fatalf("plan in account %q returned %v. strict_liquid should have failed it", account, err)
+		}
+	}
+}
+
+func TestParseAccountImportID(t *testing.T) {
+	meta := &providerMeta{
+		Client: &newClient.Client{
+			Accounts: map[string]*newClient.Client{"sandbox": {}},
+		},
+	}
+	r := resourceZendeskTrigger()
+	withAccount(r, false)
+
+	cases := map[string][2]string{
+		"sandbox/123": {"123", "sandbox"},
+		"123":         {"123", ""},
+		"other/123":   {"other/123", ""},
+	}
+
+	for input, expected := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		d.SetId(input)
+
+		if err := parseAccountImportID(d, meta); err != nil {
+			t.Fatalf("parseAccountImportID(%s) returned an error: %v", input, err)
+		}
+		if d.Id() != expected[0] || d.Get("account") != expected[1] {
+			t.Fatalf("import id %s was parsed to id %s in account %v. should have been id %s in account %s", input, d.Id(), d.Get("account"), expected[0], expected[1])
+		}
+	}
+}
+
+func TestConfigTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, cert, 0600); err != nil {
+		t.Fatalf("could not write CA bundle: %v", err)
+	}
+
+	transport, err := Config{CABundleFile: bundle, ProxyURL: "http://proxy.example.com:3128"}.transport()
+	if err != nil {
+		t.Fatalf("transport returned an error: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.zendesk.com/api/v2/triggers.json", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil || proxy == nil || proxy.Host != "proxy.example.com:3128" {
+		t.Fatalf("transport did not use the configured proxy. proxy was %v", proxy)
+	}
+
+	transport.Proxy = nil
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request to a server signed by the CA bundle failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := (Config{CABundleFile: filepath.Join(t.TempDir(), "missing.pem")}).transport(); err == nil {
+		t.Fatal("transport did not return an error for a missing CA bundle")
+	}
+}
+
+func TestConfigOAuthTokenURL(t *testing.T) {
+	cases := map[string]Config{
+		"https://example.zendesk.com/oauth/tokens": {Account: "example"},
+		"http://localhost:8080/oauth/tokens":       {Account: "example", APIURL: "http://localhost:8080/api/v2/"},
+		"https://sandbox.example.com/oauth/tokens": {APIURL: "https://sandbox.example.com/api/v2"},
+	}
+
+	for expected, config := range cases {
+		if v := config.oauthTokenURL(); v != expected {
+			t.Fatalf("oauthTokenURL was %s. should have been %s", v, expected)
+		}
+	}
+}
+
+func TestFakeZendeskResources(t *testing.T) {
+	server := fakezendesk.NewServer()
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_url":     server.URL + "/api/v2",
+		"oauth_token": "fake",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("providerConfigure returned an error: %v", diags)
+	}
+
+	cases := []struct {
+		resourceType string
+		raw          map[string]interface{}
+		attribute    string
+	}{
+		{"zendesk_group", map[string]interface{}{"name": "Tier 2"}, "name"},
+		{"zendesk_brand", map[string]interface{}{"name": "Support", "subdomain": "support"}, "subdomain"},
+		{"zendesk_ticket_field", map[string]interface{}{
+			"title": "Plan",
+			"type":  "tagger",
+			"custom_field_option": []interface{}{
+				map[string]interface{}{"name": "Free", "value": "free"},
+			},
+		}, "title"},
+		{"zendesk_trigger", map[string]interface{}{
+			"title":  "Escalate VIP",
+			"all":    []interface{}{map[string]interface{}{"field": "priority", "operator": "is", "value": "urgent"}},
+			"action": []interface{}{map[string]interface{}{"field": "status", "value": "open"}},
+		}, "title"},
+		{"zendesk_webhook", map[string]interface{}{
+			"name":           "Slack",
+			"endpoint":       "https://example.com/hook",
+			"http_method":    "POST",
+			"request_format": "json",
+			"status":         "active",
+			"subscriptions":  []interface{}{"conditional_ticket_events"},
+		}, "name"},
+		{"zendesk_queues", map[string]interface{}{
+			"name":           "Escalations",
+			"all":            []interface{}{map[string]interface{}{"field": "priority", "operator": "is", "value": "urgent"}},
+			"primary_groups": []interface{}{1},
+		}, "name"},
+		{"zendesk_custom_roles", map[string]interface{}{
+			"name": "Light agent",
+			"configuration": []interface{}{map[string]interface{}{
+				"ticket_editing": true,
+			}},
+		}, "name"},
+		{"zendesk_dynamic_content", map[string]interface{}{"name": "Greeting"}, "name"},
+		{"zendesk_ticket_form", map[string]interface{}{
+			"name": "Snowboard Problem",
+			"display_name_variant": []interface{}{
+				map[string]interface{}{"locale": "en-US", "content": "Snowboard problem"},
+				map[string]interface{}{"locale": "de", "content": "Snowboard-Problem"},
+			},
+		}, "name"},
+	}
+
+	for _, c := range cases {
+		r := Provider().ResourcesMap[c.resourceType]
+		d := schema.TestResourceDataRaw(t, r.Schema, c.raw)
+
+		if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("create of %s returned an error: %v", c.resourceType, diags)
+		}
+		if d.Id() == "" {
+			t.Fatalf("create of %s did not set the id", c.resourceType)
+		}
+
+		if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("read of %s returned an error: %v", c.resourceType, diags)
+		}
+		if v := d.Get(c.attribute); v != c.raw[c.attribute] {
+			t.Fatalf("%s had %s %v. should have been %v", c.resourceType, c.attribute, v, c.raw[c.attribute])
+		}
+
+		if diags := r.DeleteContext(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("delete of %s returned an error: %v", c.resourceType, diags)
+		}
+	}
+}
+
+// cassetteModeVar set to record makes testCassetteProvider record cassettes
+const cassetteModeVar = "ZENDESK_CASSETTE_MODE"
+
+// testCassetteProvider configures the provider to replay testdata/cassettes/<name>.json.
+// With ZENDESK_CASSETTE_MODE=record, the cassette is recorded from the account
+// configured by the environment instead.
+func testCassetteProvider(t *testing.T, name string) interface{} {
+	path := filepath.Join("testdata", "cassettes", name+".json")
+	mode := newClient.CassetteMode(os.Getenv(cassetteModeVar))
+
+	testTransport = func(base http.RoundTripper) (http.RoundTripper, error) {
+		return newClient.NewCassetteTransport(base, path, mode)
+	}
+	t.Cleanup(func() { testTransport = nil })
+
+	raw := map[string]interface{}{}
+	if mode != newClient.CassetteRecord {
+		mode = newClient.CassetteReplay
+		for _, v := range []string{accountVar, emailVar, tokenVar, oauthTokenVar, clientIDVar, clientSecretVar, apiURLVar} {
+			t.Setenv(v, "")
+		}
+		raw = map[string]interface{}{
+			"api_url":     "https://example.zendesk.com/api/v2",
+			"oauth_token": "replay",
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+	meta, diags := providerConfigure(context.Background(), d, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("providerConfigure returned an error: %v", diags)
+	}
+	return meta
+}
+
+// testCassetteLifecycle creates, reads, updates and deletes a resource through the provider meta.
+// It returns the resource data as read after the creation.
+func testCassetteLifecycle(t *testing.T, meta interface{}, resourceType string, create, update map[string]interface{}) *schema.ResourceData {
+	ctx := context.Background()
+	r := Provider().ResourcesMap[resourceType]
+
+	d := schema.TestResourceDataRaw(t, r.Schema, create)
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create of %s returned an error: %v", resourceType, diags)
+	}
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read of %s returned an error: %v", resourceType, diags)
+	}
+
+	updated := schema.TestResourceDataRaw(t, r.Schema, update)
+	updated.SetId(d.Id())
+	if diags := r.UpdateContext(ctx, updated, meta); diags.HasError() {
+		t.Fatalf("update of %s returned an error: %v", resourceType, diags)
+	}
+
+	if diags := r.DeleteContext(ctx, updated, meta); diags.HasError() {
+		t.Fatalf("delete of %s returned an error: %v", resourceType, diags)
+	}
+
+	return d
+}
+
+func TestProviderConfigureIgnoresCassetteEnv(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"trigger":{"id":1,"title":"Auto reply"}}`))
+	}))
+	defer server.Close()
+
+	cassette := filepath.Join(t.TempDir(), "trigger.json")
+	t.Setenv("ZENDESK_CASSETTE", cassette)
+	t.Setenv(cassetteModeVar, string(newClient.CassetteRecord))
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_url":     server.URL + "/api/v2",
+		"oauth_token": "xxx",
+	})
+	meta, diags := providerConfigure(context.Background(), d, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("providerConfigure returned an error: %v", diags)
+	}
+	if _, err := meta.(*providerMeta).GetTrigger(context.Background(), 1); err != nil {
+		t.Fatalf("GetTrigger returned an error: %v", err)
+	}
+
+	if _, err := os.Stat(cassette); !os.IsNotExist(err) {
+		t.Fatalf("the provider recorded API traffic to %s because of the environment", cassette)
+	}
+}
+
+func TestCassetteReplayMismatch(t *testing.T) {
+	meta := testCassetteProvider(t, "trigger")
+
+	_, err := meta.(*providerMeta).GetTrigger(context.Background(), 1)
+	if err == nil || !strings.Contains(err.Error(), "was recorded") {
+		t.Fatalf("a request which was not recorded returned %v. should have been a cassette mismatch", err)
+	}
+}
@@ -200,6 +200,9 @@ func readAttachment(ctx context.Context, d identifiableGetterSetter, zd zendesk.
 	}
 
 	a, err := zd.GetAttachment(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
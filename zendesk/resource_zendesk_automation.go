@@ -2,11 +2,10 @@ package zendesk
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	client "github.com/nukosuke/go-zendesk/zendesk"
 )
@@ -34,6 +33,11 @@ func resourceZendeskAutomation() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			validateConditionsDiff,
+			validateActionsDiff,
+			lintLiquidDiff("action", webhookNotificationKey),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"title": {
@@ -48,33 +52,16 @@ func resourceZendeskAutomation() *schema.Resource {
 				Default:     true,
 			},
 			"position": {
-				Description: "The position of the automation which specifies the order it will be executed.",
+				Description: "The position of the automation which specifies the order it will be executed. Leave it unset when the automation is listed in `zendesk_automation_order`, since both would set the position and undo each other on every apply.",
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
 			},
 			// Both the "all" and "any" parameter are optional, but at least one of them must be supplied
-			"all": automationConditionSchema("Logical AND. All the conditions must be met."),
-			"any": automationConditionSchema("Logical OR. Any condition can be met."),
-			"action": {
-				Description: "What the automation will do.",
-				Type:        schema.TypeSet,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"field": {
-							Description: "The name of a ticket field to modify.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"value": {
-							Description: "The new value of the field. Can be a single string value or a jsonencode'ed list",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
-				},
-				Required: true,
-			},
+			"all":                  automationConditionSchema("Logical AND. All the conditions must be met."),
+			"any":                  automationConditionSchema("Logical OR. Any condition can be met."),
+			"action":               actionSchema("What the automation will do.", webhookNotificationKey),
+			webhookNotificationKey: webhookNotificationSchema("Webhooks the automation calls. Each block is sent as a `notification_webhook` action."),
 		},
 	}
 }
@@ -109,28 +96,17 @@ func marshalAutomation(automation client.Automation, d identifiableGetterSetter)
 	}
 	fields["any"] = anys
 
-	var actions []map[string]interface{}
+	var ruleActions []ruleAction
 	for _, action := range automation.Actions {
+		ruleActions = append(ruleActions, ruleAction{Field: action.Field, Value: action.Value})
+	}
 
-		// If the automation value is a string, leave it be
-		// If it's a list, marshal it to a string
-		var stringVal string
-		switch action.Value.(type) {
-		case []interface{}:
-			tmp, err := json.Marshal(action.Value)
-			if err != nil {
-				return fmt.Errorf("error decoding automation action value: %s", err)
-			}
-			stringVal = string(tmp)
-		case string:
-			stringVal = action.Value.(string)
-		}
+	ruleActions, webhooks := flattenWebhookNotifications(ruleActions, d.Get("action"))
+	fields[webhookNotificationKey] = webhooks
 
-		m := map[string]interface{}{
-			"field": action.Field,
-			"value": stringVal,
-		}
-		actions = append(actions, m)
+	actions, err := flattenActions(ruleActions, d.Get("action"))
+	if err != nil {
+		return fmt.Errorf("error decoding automation action value: %s", err)
 	}
 	fields["action"] = actions
 	return setSchemaFields(d, fields)
@@ -194,29 +170,29 @@ func unmarshalAutomation(d identifiableGetterSetter) (client.Automation, error)
 		automation.Conditions.Any = conditions
 	}
 
+	var ruleActions []ruleAction
 	if v, ok := d.GetOk("action"); ok {
-		automationActions := v.(*schema.Set).List()
-		actions := []client.AutomationAction{}
-		for _, a := range automationActions {
-			action, ok := a.(map[string]interface{})
-			if !ok {
-				return automation, fmt.Errorf("could not parse actions for automation %v", automation)
-			}
+		actions, err := expandActions(v)
+		if err != nil {
+			return automation, fmt.Errorf("could not parse actions for automation %v: %v", automation, err)
+		}
+		ruleActions = append(ruleActions, actions...)
+	}
 
-			// If the action value is a list, unmarshal it
-			var actionValue interface{}
-			if strings.HasPrefix(action["value"].(string), "[") {
-				err := json.Unmarshal([]byte(action["value"].(string)), &actionValue)
-				if err != nil {
-					return automation, fmt.Errorf("error unmarshalling automation action value: %s", err)
-				}
-			} else {
-				actionValue = action["value"]
-			}
+	if v, ok := d.GetOk(webhookNotificationKey); ok {
+		webhooks, err := expandWebhookNotifications(v)
+		if err != nil {
+			return automation, fmt.Errorf("could not parse webhook notifications for automation %v: %v", automation, err)
+		}
+		ruleActions = append(ruleActions, webhooks...)
+	}
 
+	if len(ruleActions) > 0 {
+		actions := []client.AutomationAction{}
+		for _, action := range ruleActions {
 			actions = append(actions, client.AutomationAction{
-				Field: action["field"].(string),
-				Value: actionValue,
+				Field: action.Field,
+				Value: action.Value,
 			})
 		}
 		automation.Actions = actions
@@ -257,6 +233,9 @@ func readAutomation(ctx context.Context, d identifiableGetterSetter, zd client.A
 	}
 
 	automation, err := zd.GetAutomation(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -318,14 +297,16 @@ func automationConditionSchema(desc string) *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"field": {
-					Description: "The name of a ticket field.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "The name of a ticket field. Custom fields are written as `custom_fields_<id>`.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionField(conditionAutomation),
 				},
 				"operator": {
-					Description: "A comparison operator.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "A comparison operator.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionOperator(),
 				},
 				"value": {
 					Description: "The value of a ticket field.",
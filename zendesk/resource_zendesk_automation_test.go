@@ -107,6 +107,24 @@ func TestReadAutomation(t *testing.T) {
 	}
 }
 
+func TestReadAutomationNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := newIdentifiableGetterSetter()
+	i.SetId("12345")
+
+	m.EXPECT().GetAutomation(gomock.Any(), gomock.Eq(int64(12345))).Return(zendesk.Automation{}, newZendeskError(http.StatusNotFound))
+	if diags := readAutomation(context.Background(), i, m); len(diags) != 0 {
+		t.Fatalf("readAutomation returned an error for a deleted automation: %v", diags)
+	}
+
+	if v := i.Id(); v != "" {
+		t.Fatalf("readAutomation did not remove the automation from state. Id was %s", v)
+	}
+}
+
 func TestUpdateAutomation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -31,9 +31,12 @@ func resourceZendeskBrand() *schema.Resource {
 			return deleteBrand(ctx, d, zd)
 		},
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importByAttribute("/brands.json", "brands",
+			func(b client.Brand) int64 { return b.ID },
+			map[string]func(client.Brand) string{
+				"name":      func(b client.Brand) string { return b.Name },
+				"subdomain": func(b client.Brand) string { return b.Subdomain },
+			}),
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -219,6 +222,9 @@ func readBrand(ctx context.Context, d identifiableGetterSetter, zd client.BrandA
 	}
 
 	brand, err := zd.GetBrand(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -3,12 +3,156 @@ package zendesk
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
 )
 
+// customRolePermission describes a permission of a custom role configuration.
+// Permissions without values are booleans.
+type customRolePermission struct {
+	description string
+	values      []string
+}
+
+// customRolePermissions lists the documented permissions of a custom role configuration.
+// ref: https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/#configuration
+var customRolePermissions = map[string]customRolePermission{
+	"assign_tickets_to_any_group":      {description: "Whether the agent can assign tickets to any group."},
+	"chat_access":                      {description: "Whether the agent has access to Chat."},
+	"end_user_list_access":             {description: "Whether the agent can list end users.", values: []string{"full", "none"}},
+	"end_user_profile_access":          {description: "What the agent can do with end user profiles.", values: []string{"edit", "edit-within-org", "full", "readonly"}},
+	"explore_access":                   {description: "What the agent can do in Explore.", values: []string{"edit", "full", "none", "readonly"}},
+	"forum_access":                     {description: "What the agent can do in the Help Center community.", values: []string{"edit-topics", "full", "readonly"}},
+	"forum_access_restricted_content":  {description: "Whether the agent can see restricted Help Center content."},
+	"group_access":                     {description: "Whether the agent can add or modify groups."},
+	"light_agent":                      {description: "Whether the role is a light agent role."},
+	"macro_access":                     {description: "What the agent can do with macros.", values: []string{"full", "manage-group", "manage-personal", "readonly"}},
+	"manage_automations":               {description: "Whether the agent can manage automations."},
+	"manage_business_rules":            {description: "Whether the agent can manage business rules."},
+	"manage_contextual_workspaces":     {description: "Whether the agent can manage contextual workspaces."},
+	"manage_dynamic_content":           {description: "Whether the agent can manage dynamic content."},
+	"manage_extensions_and_channels":   {description: "Whether the agent can manage apps, integrations and channels."},
+	"manage_facebook":                  {description: "Whether the agent can manage Facebook pages."},
+	"manage_group_memberships":         {description: "Whether the agent can manage group memberships."},
+	"manage_groups":                    {description: "Whether the agent can manage groups."},
+	"manage_macro_content_suggestions": {description: "Whether the agent can manage macro content suggestions."},
+	"manage_organization_fields":       {description: "Whether the agent can manage organization fields."},
+	"manage_organizations":             {description: "Whether the agent can manage organizations."},
+	"manage_roles":                     {description: "Which roles the agent can manage.", values: []string{"all-except-self", "none"}},
+	"manage_slas":                      {description: "Whether the agent can manage SLA policies."},
+	"manage_team_members":              {description: "What the agent can do with team members.", values: []string{"all", "none", "readonly"}},
+	"manage_ticket_fields":             {description: "Whether the agent can manage ticket fields."},
+	"manage_ticket_forms":              {description: "Whether the agent can manage ticket forms."},
+	"manage_triggers":                  {description: "Whether the agent can manage triggers."},
+	"manage_user_fields":               {description: "Whether the agent can manage user fields."},
+	"moderate_forums":                  {description: "Whether the agent can moderate the Help Center community."},
+	"organization_editing":             {description: "Whether the agent can edit organizations."},
+	"organization_notes_editing":       {description: "Whether the agent can edit organization notes."},
+	"report_access":                    {description: "What the agent can do with reports.", values: []string{"full", "none", "readonly"}},
+	"side_conversation_create":         {description: "Whether the agent can create side conversations."},
+	"ticket_access":                    {description: "Which tickets the agent can access.", values: []string{"all", "assigned-only", "within-groups", "within-groups-and-public-groups", "within-organization"}},
+	"ticket_bulk_edit":                 {description: "Whether the agent can edit several tickets at once."},
+	"ticket_comment_access":            {description: "Which comments the agent can add.", values: []string{"none", "public"}},
+	"ticket_deletion":                  {description: "Whether the agent can delete tickets."},
+	"ticket_editing":                   {description: "Whether the agent can edit ticket properties."},
+	"ticket_merge":                     {description: "Whether the agent can merge tickets."},
+	"ticket_tag_editing":               {description: "Whether the agent can edit ticket tags."},
+	"twitter_search_access":            {description: "Whether the agent can search X (formerly Twitter)."},
+	"user_view_access":                 {description: "What the agent can do with customer lists.", values: []string{"full", "manage-group", "manage-personal", "none", "readonly"}},
+	"view_access":                      {description: "What the agent can do with views.", values: []string{"full", "manage-group", "manage-personal", "playonly", "readonly"}},
+	"view_deleted_tickets":             {description: "Whether the agent can see deleted tickets."},
+	"view_filter_tickets":              {description: "Whether the agent can filter the tickets of views."},
+	"voice_access":                     {description: "Whether the agent can answer calls."},
+	"voice_dashboard_access":           {description: "Whether the agent can see the Talk dashboard."},
+}
+
+// customRoleConfigurationSchema builds the attributes of the configuration block from customRolePermissions
+func customRoleConfigurationSchema() map[string]*schema.Schema {
+	attributes := map[string]*schema.Schema{}
+	for name, permission := range customRolePermissions {
+		if permission.values == nil {
+			attributes[name] = &schema.Schema{
+				Description: permission.description,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			}
+			continue
+		}
+
+		attributes[name] = &schema.Schema{
+			Description:  fmt.Sprintf("%s Allowed values are %s.", permission.description, strings.Join(permission.values, ", ")),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice(permission.values, false),
+		}
+	}
+	return attributes
+}
+
+// flattenCustomRoleConfiguration converts the permissions returned by the API
+// to the types of the configuration block. Permissions missing from
+// customRolePermissions are dropped.
+func flattenCustomRoleConfiguration(configuration map[string]interface{}) (map[string]interface{}, error) {
+	block := map[string]interface{}{}
+	for name, permission := range customRolePermissions {
+		v, ok := configuration[name]
+		if !ok || v == nil {
+			continue
+		}
+
+		if permission.values != nil {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("custom role permission %s should be a string, got %v", name, v)
+			}
+			block[name] = s
+			continue
+		}
+
+		switch b := v.(type) {
+		case bool:
+			block[name] = b
+		case string:
+			parsed, err := strconv.ParseBool(b)
+			if err != nil {
+				return nil, fmt.Errorf("custom role permission %s should be a boolean, got %q", name, b)
+			}
+			block[name] = parsed
+		default:
+			return nil, fmt.Errorf("custom role permission %s should be a boolean, got %v", name, v)
+		}
+	}
+	return block, nil
+}
+
+// expandCustomRoleConfiguration converts the configuration block to the permissions sent to the API.
+// Enumerated permissions which aren't set are left to Zendesk, booleans are always sent.
+func expandCustomRoleConfiguration(block map[string]interface{}) map[string]interface{} {
+	configuration := map[string]interface{}{}
+	for name, permission := range customRolePermissions {
+		v, ok := block[name]
+		if !ok || v == nil {
+			continue
+		}
+
+		if permission.values != nil {
+			if s := v.(string); s != "" {
+				configuration[name] = s
+			}
+			continue
+		}
+		configuration[name] = v.(bool)
+	}
+	return configuration
+}
+
 // https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/
 func resourceZendeskCustomRoles() *schema.Resource {
 	return &schema.Resource{
@@ -55,10 +199,14 @@ func resourceZendeskCustomRoles() *schema.Resource {
 				Optional:    true,
 			},
 			"configuration": {
-				Description: "Configuration settings for the custom role.",
-				Type:        schema.TypeMap,
+				Description: "The permissions of the custom role. Boolean permissions which aren't set are turned off, the others keep the value Zendesk gives them.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
 				Optional:    true,
-				Elem:        schema.TypeString,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: customRoleConfigurationSchema(),
+				},
 			},
 			"created_at": {
 				Description: "The time the custom role was created.",
@@ -85,7 +233,11 @@ func marshalCustomRole(role newClient.CustomRole, d identifiableGetterSetter) er
 	}
 
 	if role.Configuration != nil {
-		fields["configuration"] = role.Configuration
+		configuration, err := flattenCustomRoleConfiguration(role.Configuration)
+		if err != nil {
+			return err
+		}
+		fields["configuration"] = []map[string]interface{}{configuration}
 	}
 
 	err := setSchemaFields(d, fields)
@@ -124,10 +276,9 @@ func unmarshalCustomRole(d identifiableGetterSetter) (newClient.CustomRole, erro
 	}
 
 	if v, ok := d.GetOk("configuration"); ok {
-		configMap := v.(map[string]interface{})
-		role.Configuration = make(map[string]interface{})
-		for k, val := range configMap {
-			role.Configuration[k] = val
+		blocks := v.([]interface{})
+		if len(blocks) > 0 && blocks[0] != nil {
+			role.Configuration = expandCustomRoleConfiguration(blocks[0].(map[string]interface{}))
 		}
 	}
 
@@ -166,6 +317,9 @@ func readCustomRole(ctx context.Context, d identifiableGetterSetter, zd *newClie
 	}
 
 	role, err := zd.GetCustomRole(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -1,7 +1,14 @@
 package zendesk
 
 import (
+	"context"
+	"reflect"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/nukosuke/terraform-provider-zendesk/internal/fakezendesk"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
 )
 
 // Basic test structure - actual implementation would require mock client
@@ -10,3 +17,167 @@ func TestResourceZendeskCustomRoles(t *testing.T) {
 	// This is a placeholder test file structure
 }
 
+func TestMarshalCustomRoleConfiguration(t *testing.T) {
+	role := newClient.CustomRole{
+		ID:   1,
+		Name: "Tier 1",
+		Configuration: map[string]interface{}{
+			"ticket_access":   "within-groups",
+			"ticket_deletion": true,
+			"ticket_merge":    "false",
+			"unknown_setting": "ignored",
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceZendeskCustomRoles().Schema, map[string]interface{}{})
+	d.SetId("1")
+	if err := marshalCustomRole(role, d); err != nil {
+		t.Fatalf("marshalCustomRole returned an error: %v", err)
+	}
+
+	if v := d.Get("configuration.0.ticket_access"); v != "within-groups" {
+		t.Fatalf("custom role had ticket_access %v. should have been within-groups", v)
+	}
+	if v := d.Get("configuration.0.ticket_deletion"); v != true {
+		t.Fatalf("custom role had ticket_deletion %v. should have been true", v)
+	}
+
+	result, err := unmarshalCustomRole(d)
+	if err != nil {
+		t.Fatalf("unmarshalCustomRole returned an error: %v", err)
+	}
+	if v := result.Configuration["ticket_merge"]; v != false {
+		t.Fatalf("custom role had ticket_merge %#v. should have been converted to false", v)
+	}
+	if v := result.Configuration["ticket_access"]; v != "within-groups" {
+		t.Fatalf("custom role had ticket_access %v. should have been within-groups", v)
+	}
+	if _, ok := result.Configuration["unknown_setting"]; ok {
+		t.Fatal("custom role should not have sent a permission missing from the configuration block")
+	}
+	if _, ok := result.Configuration["view_access"]; ok {
+		t.Fatal("custom role should not have sent an enumerated permission which isn't set")
+	}
+}
+
+func TestMarshalCustomRoleConfigurationInvalidType(t *testing.T) {
+	role := newClient.CustomRole{
+		Configuration: map[string]interface{}{
+			"ticket_deletion": "sometimes",
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceZendeskCustomRoles().Schema, map[string]interface{}{})
+	if err := marshalCustomRole(role, d); err == nil {
+		t.Fatal("marshalCustomRole should have failed for a boolean permission which isn't a boolean")
+	}
+}
+
+func TestCustomRoleConfigurationSchema(t *testing.T) {
+	attributes := customRoleConfigurationSchema()
+
+	if v := attributes["light_agent"].Type; v != schema.TypeBool {
+		t.Fatalf("light_agent had type %v. should have been TypeBool", v)
+	}
+
+	ticketAccess := attributes["ticket_access"]
+	if _, errs := ticketAccess.ValidateFunc("within-groups", "ticket_access"); len(errs) != 0 {
+		t.Fatalf("ticket_access should have accepted within-groups: %v", errs)
+	}
+	if _, errs := ticketAccess.ValidateFunc("within_groups", "ticket_access"); len(errs) == 0 {
+		t.Fatal("ticket_access should have rejected within_groups")
+	}
+}
+
+func TestUnmarshalCustomRoleConfiguration(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceZendeskCustomRoles().Schema, map[string]interface{}{
+		"name": "Tier 1",
+		"configuration": []interface{}{
+			map[string]interface{}{
+				"ticket_access":         "assigned-only",
+				"manage_business_rules": true,
+			},
+		},
+	})
+
+	role, err := unmarshalCustomRole(d)
+	if err != nil {
+		t.Fatalf("unmarshalCustomRole returned an error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"ticket_access":         "assigned-only",
+		"manage_business_rules": true,
+	}
+	for name, value := range expected {
+		if v := role.Configuration[name]; !reflect.DeepEqual(v, value) {
+			t.Fatalf("custom role had %s %#v. should have been %#v", name, v, value)
+		}
+	}
+	if v := role.Configuration["ticket_deletion"]; v != false {
+		t.Fatalf("custom role had ticket_deletion %#v. should have been false", v)
+	}
+}
+
+func TestUpdateCustomRoleRevokesRemovedPermission(t *testing.T) {
+	server := fakezendesk.NewServer()
+	defer server.Close()
+
+	p := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_url":     server.URL + "/api/v2",
+		"oauth_token": "fake",
+	})
+	meta, diags := providerConfigure(context.Background(), p, "terraform-provider-zendesk/test")
+	if diags.HasError() {
+		t.Fatalf("providerConfigure returned an error: %v", diags)
+	}
+
+	config := func(permissions map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":          "Tier 1",
+			"configuration": []interface{}{permissions},
+		}
+	}
+
+	r := Provider().ResourcesMap["zendesk_custom_roles"]
+	d := schema.TestResourceDataRaw(t, r.Schema, config(map[string]interface{}{
+		"ticket_editing":  true,
+		"ticket_deletion": true,
+	}))
+	if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("create returned an error: %v", diags)
+	}
+
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config(map[string]interface{}{
+		"ticket_editing": true,
+	})), meta)
+	if err != nil {
+		t.Fatalf("Diff returned an error: %v", err)
+	}
+	if diff == nil {
+		t.Fatal("Diff was empty. should have turned the removed permission off")
+	}
+	if attr, ok := diff.Attributes["configuration.0.ticket_deletion"]; !ok || attr.New != "false" {
+		t.Fatalf("Diff had ticket_deletion %+v. should have turned the removed permission off", attr)
+	}
+
+	if _, diags := r.Apply(context.Background(), state, diff, meta); diags.HasError() {
+		t.Fatalf("update returned an error: %v", diags)
+	}
+
+	id, err := atoi64(state.ID)
+	if err != nil {
+		t.Fatalf("could not parse custom role id %s: %v", state.ID, err)
+	}
+	role, err := meta.(*providerMeta).GetCustomRole(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetCustomRole returned an error: %v", err)
+	}
+	if v := role.Configuration["ticket_deletion"]; v != false {
+		t.Fatalf("custom role had ticket_deletion %v after the update. should have been false", v)
+	}
+	if v := role.Configuration["ticket_editing"]; v != true {
+		t.Fatalf("custom role had ticket_editing %v after the update. should have been true", v)
+	}
+}
@@ -197,6 +197,9 @@ func readCustomStatus(ctx context.Context, d identifiableGetterSetter, zd *newCl
 	}
 
 	status, err := zd.GetCustomStatus(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
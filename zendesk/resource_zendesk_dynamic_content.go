@@ -136,6 +136,9 @@ func readDynamicContent(ctx context.Context, d identifiableGetterSetter, zd clie
 	}
 
 	dc, err := zd.GetDynamicContentItem(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -34,15 +34,17 @@ func resourceZendeskDynamicContentVariant() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: lintLiquidDiff("content"),
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"content": {
-				Type:        schema.TypeString,
-				Description: "Content of the dynamic content variant",
-				Required:    true,
+				Type:             schema.TypeString,
+				Description:      "Content of the dynamic content variant",
+				Required:         true,
+				ValidateDiagFunc: validateLiquid(),
 			},
 			"locale_id": {
 				Type:        schema.TypeInt,
@@ -113,8 +115,6 @@ func unmarshalDynamicContentVariant(d identifiableGetterSetter) (DynamicContentV
 	if v, ok := d.GetOk("locale_id"); ok {
 		dc.LocaleID = int64(v.(int))
 	}
-	fmt.Println("DCV Unmarshalling ->")
-	fmt.Printf("dc.LocaleID %d", dc.LocaleID)
 
 	if v, ok := d.GetOk("dynamic_content_item_id"); ok {
 		// first request is without id
@@ -129,10 +129,6 @@ func unmarshalDynamicContentVariant(d identifiableGetterSetter) (DynamicContentV
 
 	dc.Default = true
 
-	fmt.Println("Unmarshalling DCV")
-	fmt.Printf("d.Id: %s", d.Id())
-	fmt.Printf("dc.ID: %d", dc.ID)
-	fmt.Printf("dc.DynamicContentItemID: %d", dc.DynamicContentItemID)
 	return dc, nil
 }
 
@@ -169,16 +165,12 @@ func readDynamicContentVariant(ctx context.Context, d identifiableGetterSetter,
 	}
 
 	dc, err := Get(ctx, zd, dc_id, dcv_id)
-	if err != nil {
-		return diag.FromErr(err)
+	if handleNotFound(ctx, d, err) {
+		return diags
 	}
-
-	jsonData, err := json.Marshal(dc)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	fmt.Println("READ: MARSHALLED OUTPUT")
-	fmt.Println(string(jsonData))
 
 	err = marshalDynamicContentVariant(dc, d)
 	if err != nil {
@@ -254,10 +246,6 @@ func Create(ctx context.Context, z *newClient.Client, field DynamicContentVarian
 
 	result.DynamicContentVariant = field
 
-	fmt.Println("Create Payload: => ")
-	fmt.Printf("%+v\n", result)
-	fmt.Printf("%d dc_id\n", field.DynamicContentItemID)
-
 	body, err := z.Post(ctx, fmt.Sprintf("/dynamic_content/items/%d/variants.json", field.DynamicContentItemID), result)
 
 	if err != nil {
@@ -278,8 +266,6 @@ func Get(ctx context.Context, z *newClient.Client, dynamicContentItemID int64, v
 	}
 
 	body, err := z.Get(ctx, fmt.Sprintf("/dynamic_content/items/%d/variants/%d.json", dynamicContentItemID, viewID))
-	fmt.Println("GET bar")
-	fmt.Println(string(body))
 
 	if err != nil {
 		return DynamicContentVariant{}, err
@@ -303,16 +289,9 @@ func Update(ctx context.Context, z *newClient.Client, ticketID int64, field Dyna
 
 	result.DynamicContentVariant = field
 
-	fmt.Println("Update Processed payload: JSON")
-	fmt.Printf("%+v\n", result)
-
 	body, err := z.Put(ctx, fmt.Sprintf("/dynamic_content/items/%d/variants/%d.json", field.DynamicContentItemID, ticketID), result)
-	fmt.Println("Update bar")
-	fmt.Println(string(body))
 
 	if err != nil {
-		fmt.Println("Printing Error")
-		fmt.Printf("%+v\n", err)
 		return DynamicContentVariant{}, err
 	}
 
@@ -328,9 +307,6 @@ func Update(ctx context.Context, z *newClient.Client, ticketID int64, field Dyna
 // DeleteDynamicContentVariant deletes the specified ticket field
 // ref: https://developer.zendesk.com/rest_api/docs/support/user_fields#Delete-ticket-field
 func Delete(ctx context.Context, z *newClient.Client, dynamicContentItemID int64, viewID int64) error {
-	fmt.Println("Deleting something")
-	url := fmt.Sprintf("/dynamic_content/items/%d/variants/%d.json", dynamicContentItemID, viewID)
-	fmt.Printf("delete at: %s", url)
 	err := z.Delete(ctx, fmt.Sprintf("/dynamic_content/items/%d/variants/%d.json", dynamicContentItemID, viewID))
 
 	if err != nil {
@@ -30,9 +30,11 @@ func resourceZendeskGroup() *schema.Resource {
 			zd := meta.(*newClient.Client)
 			return deleteGroup(ctx, d, zd)
 		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importByAttribute("/groups.json", "groups",
+			func(g client.Group) int64 { return g.ID },
+			map[string]func(client.Group) string{
+				"name": func(g client.Group) string { return g.Name },
+			}),
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -117,6 +119,9 @@ func readGroup(ctx context.Context, d identifiableGetterSetter, zd client.GroupA
 	}
 
 	group, err := zd.GetGroup(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
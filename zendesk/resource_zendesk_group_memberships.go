@@ -151,6 +151,9 @@ func readGroupMembership(ctx context.Context, d identifiableGetterSetter, zd *ne
 	}
 
 	membership, err := zd.GetGroupMembership(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
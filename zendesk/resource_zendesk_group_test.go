@@ -3,6 +3,7 @@ package zendesk
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
 
@@ -101,6 +102,24 @@ func TestReadGroup(t *testing.T) {
 	}
 }
 
+func TestReadGroupNotFound(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := newIdentifiableGetterSetter()
+	i.SetId("12345")
+
+	m.EXPECT().GetGroup(Any(), Eq(int64(12345))).Return(zendesk.Group{}, newZendeskError(http.StatusNotFound))
+	if diags := readGroup(context.Background(), i, m); len(diags) != 0 {
+		t.Fatalf("readGroup returned an error for a deleted group: %v", diags)
+	}
+
+	if v := i.Id(); v != "" {
+		t.Fatalf("readGroup did not remove the group from state. Id was %s", v)
+	}
+}
+
 func TestCreateGroup(t *testing.T) {
 	ctrl := NewController(t)
 	defer ctrl.Finish()
@@ -2,12 +2,11 @@ package zendesk
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
 	"github.com/nukosuke/terraform-provider-zendesk/zendesk/models"
@@ -21,9 +20,15 @@ func resourceZendeskMacro() *schema.Resource {
 		ReadContext:   resourceZendeskMacrosRead,
 		UpdateContext: resourceZendeskMacrosUpdate,
 		DeleteContext: resourceZendeskMacrosDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importByAttribute("/macros.json", "macros",
+			func(m models.Macro) int64 { return m.ID },
+			map[string]func(models.Macro) string{
+				"title": func(m models.Macro) string { return m.Title },
+			}),
+		CustomizeDiff: customdiff.All(
+			validateActionsDiff,
+			lintLiquidDiff("action"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -31,25 +36,7 @@ func resourceZendeskMacro() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"action": {
-				Description: "What the macro will do.",
-				Type:        schema.TypeSet,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"field": {
-							Description: "The name of a ticket field to modify.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"value": {
-							Description: "The new value of the field. Can be a single string value or a jsonencode'ed list",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
-				},
-				Required: true,
-			},
+			"action": actionSchema("What the macro will do."),
 			"title": {
 				Description: "The title of the user field.",
 				Type:        schema.TypeString,
@@ -62,7 +49,7 @@ func resourceZendeskMacro() *schema.Resource {
 				Computed:    true,
 			},
 			"position": {
-				Description: "IMPORTANT! in order for this to take affect an update on the resource is necessary, since only that triggers a call to update position",
+				Description: "IMPORTANT! in order for this to take affect an update on the resource is necessary, since only that triggers a call to update position. Leave it unset when the macro is listed in `zendesk_macro_order`, since both would set the position and undo each other on every apply.",
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
@@ -100,8 +87,6 @@ func marshalMacros(field models.Macro, d identifiableGetterSetter) error {
 	} else {
 		var restrictions []int
 		mapi := field.Restriction.(map[string]interface{})
-		fmt.Println("marshalling to terraform")
-		fmt.Println(mapi)
 		ids := mapi["ids"]
 		if ids == nil {
 			fields["restrictions"] = nil
@@ -114,32 +99,18 @@ func marshalMacros(field models.Macro, d identifiableGetterSetter) error {
 		}
 	}
 
-	var actions []map[string]interface{}
+	var ruleActions []ruleAction
 	for _, action := range field.Actions {
+		ruleActions = append(ruleActions, ruleAction{Field: action.Field, Value: action.Value})
+	}
 
-		// If the macro	action value is a string, leave it be
-		// If it's a list, marshal it to a string
-		var stringVal string
-		switch action.Value.(type) {
-		case []interface{}:
-			tmp, err := json.Marshal(action.Value)
-			if err != nil {
-				return fmt.Errorf("error decoding macro action value: %s", err)
-			}
-			stringVal = string(tmp)
-		case string:
-			stringVal = action.Value.(string)
-		}
-
-		m := map[string]interface{}{
-			"field": action.Field,
-			"value": stringVal,
-		}
-		actions = append(actions, m)
+	actions, err := flattenActions(ruleActions, d.Get("action"))
+	if err != nil {
+		return fmt.Errorf("error decoding macro action value: %s", err)
 	}
 	fields["action"] = actions
 
-	err := setSchemaFields(d, fields)
+	err = setSchemaFields(d, fields)
 	if err != nil {
 		return err
 	}
@@ -194,28 +165,16 @@ func unmarshalMacros(d identifiableGetterSetter) (models.Macro, error) {
 	}
 
 	if v, ok := d.GetOk("action"); ok {
-		macroActions := v.(*schema.Set).List()
-		actions := []models.MacroAction{}
-		for _, a := range macroActions {
-			action, ok := a.(map[string]interface{})
-			if !ok {
-				return tf, fmt.Errorf("could not parse actions for macro %v", tf)
-			}
-
-			// If the action value is a list, unmarshal it
-			var actionValue interface{}
-			if strings.HasPrefix(action["value"].(string), "[") {
-				err := json.Unmarshal([]byte(action["value"].(string)), &actionValue)
-				if err != nil {
-					return tf, fmt.Errorf("error unmarshalling macro action value: %s", err)
-				}
-			} else {
-				actionValue = action["value"]
-			}
+		ruleActions, err := expandActions(v)
+		if err != nil {
+			return tf, fmt.Errorf("could not parse actions for macro %v: %v", tf, err)
+		}
 
+		actions := []models.MacroAction{}
+		for _, action := range ruleActions {
 			actions = append(actions, models.MacroAction{
-				Field: action["field"].(string),
-				Value: actionValue,
+				Field: action.Field,
+				Value: action.Value,
 			})
 		}
 		tf.Actions = actions
@@ -267,6 +226,9 @@ func readMacros(ctx context.Context, d identifiableGetterSetter, zd *newClient.C
 	}
 
 	field, err := zd.GetMacro(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
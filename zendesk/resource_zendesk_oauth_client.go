@@ -0,0 +1,277 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_clients/
+func resourceZendeskOAuthClient() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OAuth client resource.",
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return createOAuthClient(ctx, d, zd)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return readOAuthClient(ctx, d, zd)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return updateOAuthClient(ctx, d, zd)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return deleteOAuthClient(ctx, d, zd)
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Description: "The API url of this OAuth client.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the OAuth client.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"identifier": {
+				Description: "The unique identifier of the OAuth client, used as `client_id` in OAuth flows.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"kind": {
+				Description: "The kind of the OAuth client. Allowed values are \"public\" or \"confidential\".",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"public",
+					"confidential",
+				}, false),
+			},
+			"company": {
+				Description: "The name of the company that owns the OAuth client.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"description": {
+				Description: "A short description of the OAuth client.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"logo_url": {
+				Description: "The URL of the logo of the OAuth client.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"user_id": {
+				Description: "The ID of the admin who created the OAuth client.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"redirect_uri": {
+				Description: "The valid redirect URIs of the OAuth client.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"secret": {
+				Description: "The secret of the OAuth client. Zendesk only returns it when the client is created, so it is empty for imported clients.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"created_at": {
+				Description: "The time the OAuth client was created.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"updated_at": {
+				Description: "The time the OAuth client was last updated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// marshalOAuthClient encodes the provided OAuth client into the provided resource data.
+// The secret is left untouched because Zendesk masks it on every response but the create one.
+func marshalOAuthClient(oauthClient newClient.OAuthClient, d identifiableGetterSetter) error {
+	fields := map[string]interface{}{
+		"url":          oauthClient.URL,
+		"name":         oauthClient.Name,
+		"identifier":   oauthClient.Identifier,
+		"kind":         oauthClient.Kind,
+		"company":      oauthClient.Company,
+		"description":  oauthClient.Description,
+		"logo_url":     oauthClient.LogoURL,
+		"user_id":      oauthClient.UserID,
+		"redirect_uri": oauthClient.RedirectURI,
+		"created_at":   oauthClient.CreatedAt,
+		"updated_at":   oauthClient.UpdatedAt,
+	}
+
+	err := setSchemaFields(d, fields)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func unmarshalOAuthClient(d identifiableGetterSetter) (newClient.OAuthClient, error) {
+	oauthClient := newClient.OAuthClient{}
+
+	if v := d.Id(); v != "" {
+		id, err := atoi64(v)
+		if err != nil {
+			return oauthClient, fmt.Errorf("could not parse oauth client id %s: %v", v, err)
+		}
+		oauthClient.ID = id
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		oauthClient.Name = v.(string)
+	}
+
+	if v, ok := d.GetOk("identifier"); ok {
+		oauthClient.Identifier = v.(string)
+	}
+
+	if v, ok := d.GetOk("kind"); ok {
+		oauthClient.Kind = v.(string)
+	}
+
+	if v, ok := d.GetOk("company"); ok {
+		oauthClient.Company = v.(string)
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		oauthClient.Description = v.(string)
+	}
+
+	if v, ok := d.GetOk("logo_url"); ok {
+		oauthClient.LogoURL = v.(string)
+	}
+
+	if v, ok := d.GetOk("user_id"); ok {
+		oauthClient.UserID = int64(v.(int))
+	}
+
+	if v, ok := d.GetOk("redirect_uri"); ok {
+		for _, uri := range v.([]interface{}) {
+			oauthClient.RedirectURI = append(oauthClient.RedirectURI, uri.(string))
+		}
+	}
+
+	return oauthClient, nil
+}
+
+func createOAuthClient(ctx context.Context, d identifiableGetterSetter, zd newClient.OAuthClientAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	oauthClient, err := unmarshalOAuthClient(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oauthClient, err = zd.CreateOAuthClient(ctx, oauthClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", oauthClient.ID))
+
+	err = d.Set("secret", oauthClient.Secret)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = marshalOAuthClient(oauthClient, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func readOAuthClient(ctx context.Context, d identifiableGetterSetter, zd newClient.OAuthClientAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	id, err := atoi64(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oauthClient, err := zd.GetOAuthClient(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = marshalOAuthClient(oauthClient, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func updateOAuthClient(ctx context.Context, d identifiableGetterSetter, zd newClient.OAuthClientAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	oauthClient, err := unmarshalOAuthClient(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := atoi64(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oauthClient, err = zd.UpdateOAuthClient(ctx, id, oauthClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = marshalOAuthClient(oauthClient, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func deleteOAuthClient(ctx context.Context, d identifiable, zd newClient.OAuthClientAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	id, err := atoi64(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = zd.DeleteOAuthClient(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,150 @@
+package zendesk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// fakeOAuthClientAPI keeps OAuth clients in memory and masks secrets like Zendesk does
+type fakeOAuthClientAPI struct {
+	clients map[int64]newClient.OAuthClient
+	nextID  int64
+}
+
+func newFakeOAuthClientAPI() *fakeOAuthClientAPI {
+	return &fakeOAuthClientAPI{
+		clients: make(map[int64]newClient.OAuthClient),
+		nextID:  1000,
+	}
+}
+
+func (f *fakeOAuthClientAPI) GetOAuthClients(ctx context.Context) ([]newClient.OAuthClient, error) {
+	var result []newClient.OAuthClient
+	for _, c := range f.clients {
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func (f *fakeOAuthClientAPI) GetOAuthClient(ctx context.Context, id int64) (newClient.OAuthClient, error) {
+	c, ok := f.clients[id]
+	if !ok {
+		return newClient.OAuthClient{}, newZendeskError(http.StatusNotFound)
+	}
+	return c, nil
+}
+
+func (f *fakeOAuthClientAPI) CreateOAuthClient(ctx context.Context, c newClient.OAuthClient) (newClient.OAuthClient, error) {
+	f.nextID++
+	c.ID = f.nextID
+	f.clients[c.ID] = c
+
+	c.Secret = "s3cr3t"
+	return c, nil
+}
+
+func (f *fakeOAuthClientAPI) UpdateOAuthClient(ctx context.Context, id int64, c newClient.OAuthClient) (newClient.OAuthClient, error) {
+	if _, ok := f.clients[id]; !ok {
+		return newClient.OAuthClient{}, newZendeskError(http.StatusNotFound)
+	}
+	c.ID = id
+	f.clients[id] = c
+	return c, nil
+}
+
+func (f *fakeOAuthClientAPI) DeleteOAuthClient(ctx context.Context, id int64) error {
+	if _, ok := f.clients[id]; !ok {
+		return newZendeskError(http.StatusNotFound)
+	}
+	delete(f.clients, id)
+	return nil
+}
+
+func TestUnmarshalOAuthClient(t *testing.T) {
+	m := &identifiableMapGetterSetter{
+		id: "100",
+		mapGetterSetter: mapGetterSetter{
+			"name":         "Integration",
+			"identifier":   "integration",
+			"kind":         "confidential",
+			"redirect_uri": []interface{}{"https://example.com/callback"},
+		},
+	}
+
+	c, err := unmarshalOAuthClient(m)
+	if err != nil {
+		t.Fatalf("unmarshal returned an error: %v", err)
+	}
+
+	if c.ID != 100 {
+		t.Fatalf("oauth client had id %d. should have been 100", c.ID)
+	}
+	if c.Identifier != "integration" {
+		t.Fatalf("oauth client had identifier %s. should have been integration", c.Identifier)
+	}
+	if len(c.RedirectURI) != 1 || c.RedirectURI[0] != "https://example.com/callback" {
+		t.Fatalf("oauth client had redirect uri %v. should have been [https://example.com/callback]", c.RedirectURI)
+	}
+}
+
+func TestCreateAndReadOAuthClient(t *testing.T) {
+	zd := newFakeOAuthClientAPI()
+	d := newIdentifiableGetterSetter()
+	d.Set("name", "Integration")
+	d.Set("identifier", "integration")
+
+	if diags := createOAuthClient(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("createOAuthClient returned an error: %v", diags)
+	}
+
+	if v := d.Id(); v != "1001" {
+		t.Fatalf("createOAuthClient did not set resource id. Id was %s", v)
+	}
+	if v := d.Get("secret"); v != "s3cr3t" {
+		t.Fatalf("createOAuthClient did not set the secret. secret was %v", v)
+	}
+
+	if diags := readOAuthClient(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("readOAuthClient returned an error: %v", diags)
+	}
+	if v := d.Get("secret"); v != "s3cr3t" {
+		t.Fatalf("readOAuthClient overwrote the secret. secret was %v", v)
+	}
+}
+
+func TestReadOAuthClientNotFound(t *testing.T) {
+	zd := newFakeOAuthClientAPI()
+	d := newIdentifiableGetterSetter()
+	d.SetId("1234")
+
+	if diags := readOAuthClient(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("readOAuthClient returned an error for a deleted oauth client: %v", diags)
+	}
+	if v := d.Id(); v != "" {
+		t.Fatalf("readOAuthClient did not remove the oauth client from state. Id was %s", v)
+	}
+}
+
+func TestReadOAuthClientsDataSource(t *testing.T) {
+	zd := newFakeOAuthClientAPI()
+	zd.CreateOAuthClient(context.Background(), newClient.OAuthClient{Name: "Integration", Identifier: "integration"})
+
+	d := newIdentifiableGetterSetter()
+	if diags := readOAuthClientsDataSource(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("readOAuthClientsDataSource returned an error: %v", diags)
+	}
+
+	clients := d.Get("clients").([]map[string]interface{})
+	if len(clients) != 1 {
+		t.Fatalf("data source returned %d clients. should have been 1", len(clients))
+	}
+	if v := clients[0]["identifier"]; v != "integration" {
+		t.Fatalf("client had identifier %v. should have been integration", v)
+	}
+	if _, ok := clients[0]["secret"]; ok {
+		t.Fatal("data source exposed the client secret")
+	}
+}
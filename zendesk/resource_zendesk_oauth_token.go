@@ -0,0 +1,274 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// oauthTokenScopes lists the scopes an OAuth token can be issued with.
+// ref: https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_tokens/#scopes
+var oauthTokenScopes = []string{
+	"read",
+	"write",
+	"impersonate",
+	"tickets:read",
+	"tickets:write",
+	"users:read",
+	"users:write",
+	"auditlogs:read",
+	"organizations:read",
+	"organizations:write",
+	"hc:read",
+	"hc:write",
+	"apps:read",
+	"apps:write",
+	"triggers:read",
+	"triggers:write",
+	"automations:read",
+	"automations:write",
+	"targets:read",
+	"targets:write",
+	"webhooks:read",
+	"webhooks:write",
+	"zis:read",
+	"zis:write",
+	"macros:read",
+	"macros:write",
+	"requests:read",
+	"requests:write",
+	"satisfaction_ratings:read",
+	"satisfaction_ratings:write",
+	"dynamic_content:read",
+	"dynamic_content:write",
+	"any_channel:write",
+	"web_widget:write",
+}
+
+// https://developer.zendesk.com/api-reference/ticketing/oauth/oauth_tokens/
+func resourceZendeskOAuthToken() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides an OAuth access token resource. Tokens cannot be updated, so any change issues a new token and revokes the old one. By default the old token is revoked before the new one is issued; set `create_before_destroy` in the `lifecycle` block to issue the new token first.",
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return createOAuthToken(ctx, d, zd)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return readOAuthToken(ctx, d, zd)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return deleteOAuthToken(ctx, d, zd)
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Description: "The API url of this OAuth token.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"client_id": {
+				Description: "The ID of the OAuth client the token is issued for.",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"scopes": {
+				Description: "The scopes of the token, e.g. `tickets:read` or `users:write`.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(oauthTokenScopes, false),
+				},
+			},
+			"expires_at": {
+				Description:  "The time the token expires, in ISO 8601 format. The token does not expire if omitted.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return sameTime(old, new)
+				},
+			},
+			"rotation_trigger": {
+				Description: "Arbitrary map of values that, when changed, forces a new token to be issued. Clients using the old token fail until they get the new one unless `create_before_destroy` is set.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"user_id": {
+				Description: "The ID of the user the token belongs to.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"token": {
+				Description: "The truncated token, suitable to identify the token in the admin UI.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"full_token": {
+				Description: "The full access token. Zendesk only returns it when the token is created, so it is empty for imported tokens.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"created_at": {
+				Description: "The time the token was created.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// marshalOAuthToken encodes the provided OAuth token into the provided resource data.
+// The full token is left untouched because it is only returned on create.
+func marshalOAuthToken(token newClient.OAuthToken, d identifiableGetterSetter) error {
+	fields := map[string]interface{}{
+		"url":        token.URL,
+		"client_id":  token.ClientID,
+		"scopes":     token.Scopes,
+		"user_id":    token.UserID,
+		"token":      token.Token,
+		"created_at": token.CreatedAt,
+	}
+
+	// keep the configured format as long as Zendesk returns the same time
+	configured, _ := d.Get("expires_at").(string)
+	if token.ExpiresAt != "" && !sameTime(configured, token.ExpiresAt) {
+		fields["expires_at"] = token.ExpiresAt
+	}
+
+	err := setSchemaFields(d, fields)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sameTime reports whether a and b are RFC 3339 times of the same instant,
+// e.g. 2030-01-01T00:00:00Z and 2030-01-01T01:00:00.000+01:00
+func sameTime(a, b string) bool {
+	t1, err := time.Parse(time.RFC3339, a)
+	if err != nil {
+		return false
+	}
+	t2, err := time.Parse(time.RFC3339, b)
+	if err != nil {
+		return false
+	}
+	return t1.Equal(t2)
+}
+
+func unmarshalOAuthToken(d identifiableGetterSetter) (newClient.OAuthToken, error) {
+	token := newClient.OAuthToken{}
+
+	if v := d.Id(); v != "" {
+		id, err := atoi64(v)
+		if err != nil {
+			return token, fmt.Errorf("could not parse oauth token id %s: %v", v, err)
+		}
+		token.ID = id
+	}
+
+	if v, ok := d.GetOk("client_id"); ok {
+		token.ClientID = int64(v.(int))
+	}
+
+	if v, ok := d.GetOk("scopes"); ok {
+		for _, scope := range v.(*schema.Set).List() {
+			token.Scopes = append(token.Scopes, scope.(string))
+		}
+	}
+
+	if v, ok := d.GetOk("expires_at"); ok {
+		token.ExpiresAt = v.(string)
+	}
+
+	return token, nil
+}
+
+func createOAuthToken(ctx context.Context, d identifiableGetterSetter, zd newClient.OAuthTokenAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	token, err := unmarshalOAuthToken(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	token, err = zd.CreateOAuthToken(ctx, token)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", token.ID))
+
+	err = d.Set("full_token", token.FullToken)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = marshalOAuthToken(token, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func readOAuthToken(ctx context.Context, d identifiableGetterSetter, zd newClient.OAuthTokenAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	id, err := atoi64(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	token, err := zd.GetOAuthToken(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = marshalOAuthToken(token, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func deleteOAuthToken(ctx context.Context, d identifiable, zd newClient.OAuthTokenAPI) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	id, err := atoi64(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = zd.RevokeOAuthToken(ctx, id)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,154 @@
+package zendesk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// fakeOAuthTokenAPI keeps OAuth tokens in memory and only returns the full token on create
+type fakeOAuthTokenAPI struct {
+	tokens map[int64]newClient.OAuthToken
+	nextID int64
+}
+
+func newFakeOAuthTokenAPI() *fakeOAuthTokenAPI {
+	return &fakeOAuthTokenAPI{
+		tokens: make(map[int64]newClient.OAuthToken),
+		nextID: 2000,
+	}
+}
+
+func (f *fakeOAuthTokenAPI) GetOAuthToken(ctx context.Context, id int64) (newClient.OAuthToken, error) {
+	token, ok := f.tokens[id]
+	if !ok {
+		return newClient.OAuthToken{}, newZendeskError(http.StatusNotFound)
+	}
+	return token, nil
+}
+
+func (f *fakeOAuthTokenAPI) CreateOAuthToken(ctx context.Context, token newClient.OAuthToken) (newClient.OAuthToken, error) {
+	f.nextID++
+	token.ID = f.nextID
+	token.Token = "abcdef"
+	f.tokens[token.ID] = token
+
+	token.FullToken = "abcdef0123456789"
+	return token, nil
+}
+
+func (f *fakeOAuthTokenAPI) RevokeOAuthToken(ctx context.Context, id int64) error {
+	if _, ok := f.tokens[id]; !ok {
+		return newZendeskError(http.StatusNotFound)
+	}
+	delete(f.tokens, id)
+	return nil
+}
+
+func TestUnmarshalOAuthToken(t *testing.T) {
+	m := &identifiableMapGetterSetter{
+		mapGetterSetter: mapGetterSetter{
+			"client_id": 1001,
+			"scopes":    schema.NewSet(schema.HashString, []interface{}{"tickets:read", "users:write"}),
+		},
+	}
+
+	token, err := unmarshalOAuthToken(m)
+	if err != nil {
+		t.Fatalf("unmarshal returned an error: %v", err)
+	}
+
+	if token.ClientID != 1001 {
+		t.Fatalf("oauth token had client id %d. should have been 1001", token.ClientID)
+	}
+	if len(token.Scopes) != 2 {
+		t.Fatalf("oauth token had scopes %v. should have had 2 scopes", token.Scopes)
+	}
+}
+
+func TestCreateReadDeleteOAuthToken(t *testing.T) {
+	zd := newFakeOAuthTokenAPI()
+	d := newIdentifiableGetterSetter()
+	d.Set("client_id", 1001)
+	d.Set("scopes", schema.NewSet(schema.HashString, []interface{}{"tickets:read"}))
+
+	if diags := createOAuthToken(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("createOAuthToken returned an error: %v", diags)
+	}
+	if v := d.Id(); v != "2001" {
+		t.Fatalf("createOAuthToken did not set resource id. Id was %s", v)
+	}
+	if v := d.Get("full_token"); v != "abcdef0123456789" {
+		t.Fatalf("createOAuthToken did not set the full token. full_token was %v", v)
+	}
+
+	if diags := readOAuthToken(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("readOAuthToken returned an error: %v", diags)
+	}
+	if v := d.Get("full_token"); v != "abcdef0123456789" {
+		t.Fatalf("readOAuthToken overwrote the full token. full_token was %v", v)
+	}
+
+	if diags := deleteOAuthToken(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("deleteOAuthToken returned an error: %v", diags)
+	}
+	if len(zd.tokens) != 0 {
+		t.Fatal("deleteOAuthToken did not revoke the token")
+	}
+
+	if diags := readOAuthToken(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("readOAuthToken returned an error for a revoked token: %v", diags)
+	}
+	if v := d.Id(); v != "" {
+		t.Fatalf("readOAuthToken did not remove the revoked token from state. Id was %s", v)
+	}
+}
+
+func TestOAuthTokenForcesReplacement(t *testing.T) {
+	s := resourceZendeskOAuthToken().Schema
+	for _, k := range []string{"client_id", "scopes", "expires_at", "rotation_trigger"} {
+		if !s[k].ForceNew {
+			t.Fatalf("%s does not force a new token", k)
+		}
+	}
+}
+
+func TestReadOAuthTokenKeepsExpiresAtFormat(t *testing.T) {
+	zd := newFakeOAuthTokenAPI()
+	zd.tokens[2001] = newClient.OAuthToken{ID: 2001, ClientID: 1001, ExpiresAt: "2030-01-01T01:00:00.000+01:00"}
+
+	d := newIdentifiableGetterSetter()
+	d.SetId("2001")
+	d.Set("expires_at", "2030-01-01T00:00:00Z")
+
+	if diags := readOAuthToken(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("readOAuthToken returned an error: %v", diags)
+	}
+	if v := d.Get("expires_at"); v != "2030-01-01T00:00:00Z" {
+		t.Fatalf("readOAuthToken changed expires_at to %v. should have kept the configured format of the same time", v)
+	}
+
+	zd.tokens[2001] = newClient.OAuthToken{ID: 2001, ClientID: 1001, ExpiresAt: "2031-01-01T00:00:00Z"}
+	if diags := readOAuthToken(context.Background(), d, zd); len(diags) != 0 {
+		t.Fatalf("readOAuthToken returned an error: %v", diags)
+	}
+	if v := d.Get("expires_at"); v != "2031-01-01T00:00:00Z" {
+		t.Fatalf("readOAuthToken did not update expires_at to another time. expires_at was %v", v)
+	}
+}
+
+func TestOAuthTokenExpiresAtDiffSuppress(t *testing.T) {
+	suppress := resourceZendeskOAuthToken().Schema["expires_at"].DiffSuppressFunc
+	if !suppress("expires_at", "2030-01-01T01:00:00.000+01:00", "2030-01-01T00:00:00Z", nil) {
+		t.Fatal("expires_at in another format of the same time should not replace the token")
+	}
+	if suppress("expires_at", "2030-01-01T00:00:00Z", "2031-01-01T00:00:00Z", nil) {
+		t.Fatal("expires_at at another time should replace the token")
+	}
+	if suppress("expires_at", "", "2030-01-01T00:00:00Z", nil) {
+		t.Fatal("adding expires_at should replace the token")
+	}
+}
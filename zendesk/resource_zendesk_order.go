@@ -0,0 +1,137 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// resourceZendeskOrder returns a resource which orders the objects of collection.
+// noun is the plural used in descriptions, e.g. "triggers".
+func resourceZendeskOrder(collection newClient.OrderedCollection, noun string) *schema.Resource {
+	return &schema.Resource{
+		Description: fmt.Sprintf("Orders %s in a single request, instead of updating the position of each of them. "+
+			"The list should contain every one of the %s, since objects left out keep their position and may end up between listed ones. "+
+			"Don't set the `position` of the listed %s in their own resources, since both would set the positions and undo each other on every apply. "+
+			"Destroying the resource leaves the order unchanged.", noun, noun, noun),
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return updateOrder(ctx, d, zd, collection)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return readOrder(ctx, d, zd, collection)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			zd := meta.(*newClient.Client)
+			return updateOrder(ctx, d, zd, collection)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("")
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateOrderDiff,
+
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Description: fmt.Sprintf("IDs of the %s in the order they should have.", noun),
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+$`), "must be a numeric ID"),
+				},
+			},
+		},
+	}
+}
+
+// validateOrderDiff rejects IDs which are listed more than once
+func validateOrderDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	seen := map[string]bool{}
+	for _, v := range d.Get("ids").([]interface{}) {
+		id, _ := v.(string)
+		// IDs of objects created in the same apply are unknown
+		if id == "" {
+			continue
+		}
+		if seen[id] {
+			return fmt.Errorf("id %s is listed more than once in ids", id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+func unmarshalOrder(d identifiableGetterSetter) ([]int64, error) {
+	var ids []int64
+	for _, v := range d.Get("ids").([]interface{}) {
+		id, err := atoi64(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse id %s: %v", v, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func readOrder(ctx context.Context, d identifiableGetterSetter, zd newClient.OrderAPI, collection newClient.OrderedCollection) diag.Diagnostics {
+	positions, err := zd.GetPositions(ctx, collection)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool {
+		return positions[i].Position < positions[j].Position
+	})
+
+	// Only the listed objects are compared, unless nothing is listed yet after an import
+	managed := map[string]bool{}
+	if v, ok := d.Get("ids").([]interface{}); ok {
+		for _, id := range v {
+			managed[id.(string)] = true
+		}
+	}
+
+	ids := []string{}
+	for _, p := range positions {
+		id := fmt.Sprintf("%d", p.ID)
+		if len(managed) == 0 || managed[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	err = d.Set("ids", ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func updateOrder(ctx context.Context, d identifiableGetterSetter, zd newClient.OrderAPI, collection newClient.OrderedCollection) diag.Diagnostics {
+	ids, err := unmarshalOrder(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = zd.Reorder(ctx, collection, ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(collection.Name)
+	return readOrder(ctx, d, zd, collection)
+}
@@ -0,0 +1,73 @@
+package zendesk
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// fakeOrderAPI keeps the positions of a single collection in memory
+type fakeOrderAPI struct {
+	positions map[int64]int64
+}
+
+func (f *fakeOrderAPI) GetPositions(ctx context.Context, collection newClient.OrderedCollection) ([]newClient.Position, error) {
+	var positions []newClient.Position
+	for id, position := range f.positions {
+		positions = append(positions, newClient.Position{ID: id, Position: position})
+	}
+	return positions, nil
+}
+
+func (f *fakeOrderAPI) Reorder(ctx context.Context, collection newClient.OrderedCollection, ids []int64) error {
+	for i, id := range ids {
+		f.positions[id] = int64(i + 1)
+	}
+	return nil
+}
+
+func TestUpdateOrder(t *testing.T) {
+	zd := &fakeOrderAPI{positions: map[int64]int64{1: 1, 2: 2, 3: 3}}
+
+	d := newIdentifiableGetterSetter()
+	d.Set("ids", []interface{}{"3", "1", "2"})
+
+	if diags := updateOrder(context.Background(), d, zd, newClient.TriggerCollection); len(diags) != 0 {
+		t.Fatalf("updateOrder returned an error: %v", diags)
+	}
+	if v := d.Id(); v != "triggers" {
+		t.Fatalf("order had id %s. should have been triggers", v)
+	}
+	if v := d.Get("ids"); !reflect.DeepEqual(v, []string{"3", "1", "2"}) {
+		t.Fatalf("order had ids %v. should have been [3 1 2]", v)
+	}
+}
+
+func TestReadOrder(t *testing.T) {
+	zd := &fakeOrderAPI{positions: map[int64]int64{1: 3, 2: 1, 3: 2, 4: 4}}
+
+	// the listed objects are read in their current order
+	d := newIdentifiableGetterSetter()
+	d.SetId("triggers")
+	d.Set("ids", []interface{}{"1", "2", "3"})
+
+	if diags := readOrder(context.Background(), d, zd, newClient.TriggerCollection); len(diags) != 0 {
+		t.Fatalf("readOrder returned an error: %v", diags)
+	}
+	if v := d.Get("ids"); !reflect.DeepEqual(v, []string{"2", "3", "1"}) {
+		t.Fatalf("order had ids %v. should have been [2 3 1]", v)
+	}
+
+	// after an import every object is read
+	d = newIdentifiableGetterSetter()
+	d.SetId("triggers")
+
+	if diags := readOrder(context.Background(), d, zd, newClient.TriggerCollection); len(diags) != 0 {
+		t.Fatalf("readOrder returned an error: %v", diags)
+	}
+	if v := d.Get("ids"); !reflect.DeepEqual(v, []string{"2", "3", "1", "4"}) {
+		t.Fatalf("order had ids %v. should have been [2 3 1 4]", v)
+	}
+}
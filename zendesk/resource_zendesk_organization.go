@@ -175,6 +175,9 @@ func readOrganization(ctx context.Context, d identifiableGetterSetter, zd client
 	}
 
 	org, err := zd.GetOrganization(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -21,9 +21,12 @@ func resourceZendeskOrganizationField() *schema.Resource {
 		ReadContext:   resourceZendeskOrganizationFieldRead,
 		UpdateContext: resourceZendeskOrganizationFieldUpdate,
 		DeleteContext: resourceZendeskOrganizationFieldDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importByAttribute("/organization_fields.json", "organization_fields",
+			func(o client.OrganizationField) int64 { return o.ID },
+			map[string]func(client.OrganizationField) string{
+				"key":   func(o client.OrganizationField) string { return o.Key },
+				"title": func(o client.OrganizationField) string { return o.Title },
+			}),
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -337,6 +340,9 @@ func readOrganizationField(ctx context.Context, d identifiableGetterSetter, zd *
 	}
 
 	field, err := GetOrganizationField(ctx, zd, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
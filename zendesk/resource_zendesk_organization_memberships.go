@@ -151,6 +151,9 @@ func readOrganizationMembership(ctx context.Context, d identifiableGetterSetter,
 	}
 
 	membership, err := zd.GetOrganizationMembership(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
 )
 
@@ -32,6 +33,7 @@ func resourceZendeskQueues() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: validateConditionsDiff,
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -49,11 +51,31 @@ func resourceZendeskQueues() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
-			"definition": {
-				Description: "The definition of the queue (JSON object).",
-				Type:        schema.TypeMap,
+			"all": queueConditionSchema("Logical AND. Tickets must meet all of the conditions to be routed to the queue."),
+			"any": queueConditionSchema("Logical OR. Tickets must meet any of the conditions to be routed to the queue."),
+			"priority": {
+				Description:  "The priority of the queue. Tickets matching several queues are routed to the one with the lowest number.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"primary_groups": {
+				Description: "IDs of the groups whose agents are offered the tickets of the queue first.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"secondary_groups": {
+				Description: "IDs of the groups whose agents are offered the tickets of the queue when no agent of the primary groups is available.",
+				Type:        schema.TypeSet,
 				Optional:    true,
-				Elem:        schema.TypeString,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
 			},
 			"created_at": {
 				Description: "The time the queue was created.",
@@ -69,17 +91,80 @@ func resourceZendeskQueues() *schema.Resource {
 	}
 }
 
-func marshalQueue(queue newClient.Queue, d identifiableGetterSetter) error {
-	fields := map[string]interface{}{
-		"url":         queue.URL,
-		"name":        queue.Name,
-		"description": queue.Description,
-		"created_at":  queue.CreatedAt,
-		"updated_at":  queue.UpdatedAt,
+func queueConditionSchema(desc string) *schema.Schema {
+	return &schema.Schema{
+		Description: desc,
+		Type:        schema.TypeSet,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Description:      "The name of a ticket field. Custom fields are written as `custom_fields_<id>`.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionField(conditionQueue),
+				},
+				"operator": {
+					Description:      "A comparison operator.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionOperator(),
+				},
+				"value": {
+					Description: "The value of a ticket field.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+			},
+		},
+		Optional: true,
 	}
+}
 
-	if queue.Definition != nil {
-		fields["definition"] = queue.Definition
+func flattenQueueConditions(conditions []newClient.QueueCondition) []map[string]interface{} {
+	var blocks []map[string]interface{}
+	for _, c := range conditions {
+		blocks = append(blocks, map[string]interface{}{
+			"field":    c.Field,
+			"operator": c.Operator,
+			"value":    c.Value,
+		})
+	}
+	return blocks
+}
+
+func expandQueueConditions(v interface{}) []newClient.QueueCondition {
+	conditions := []newClient.QueueCondition{}
+	for _, c := range v.(*schema.Set).List() {
+		condition := c.(map[string]interface{})
+		conditions = append(conditions, newClient.QueueCondition{
+			Field:    condition["field"].(string),
+			Operator: condition["operator"].(string),
+			Value:    condition["value"].(string),
+		})
+	}
+	return conditions
+}
+
+func expandQueueGroups(v interface{}) []int64 {
+	var ids []int64
+	for _, id := range v.(*schema.Set).List() {
+		ids = append(ids, int64(id.(int)))
+	}
+	return ids
+}
+
+func marshalQueue(queue newClient.Queue, d identifiableGetterSetter) error {
+	fields := map[string]interface{}{
+		"url":              queue.URL,
+		"name":             queue.Name,
+		"description":      queue.Description,
+		"all":              flattenQueueConditions(queue.Definition.All),
+		"any":              flattenQueueConditions(queue.Definition.Any),
+		"priority":         queue.Priority,
+		"primary_groups":   queue.PrimaryGroups,
+		"secondary_groups": queue.SecondaryGroups,
+		"created_at":       queue.CreatedAt,
+		"updated_at":       queue.UpdatedAt,
 	}
 
 	err := setSchemaFields(d, fields)
@@ -113,12 +198,24 @@ func unmarshalQueue(d identifiableGetterSetter) (newClient.Queue, error) {
 		queue.Description = v.(string)
 	}
 
-	if v, ok := d.GetOk("definition"); ok {
-		configMap := v.(map[string]interface{})
-		queue.Definition = make(map[string]interface{})
-		for k, val := range configMap {
-			queue.Definition[k] = val
-		}
+	if v, ok := d.GetOk("all"); ok {
+		queue.Definition.All = expandQueueConditions(v)
+	}
+
+	if v, ok := d.GetOk("any"); ok {
+		queue.Definition.Any = expandQueueConditions(v)
+	}
+
+	if v, ok := d.GetOk("priority"); ok {
+		queue.Priority = int64(v.(int))
+	}
+
+	if v, ok := d.GetOk("primary_groups"); ok {
+		queue.PrimaryGroups = expandQueueGroups(v)
+	}
+
+	if v, ok := d.GetOk("secondary_groups"); ok {
+		queue.SecondaryGroups = expandQueueGroups(v)
 	}
 
 	return queue, nil
@@ -156,6 +253,9 @@ func readQueue(ctx context.Context, d identifiableGetterSetter, zd *newClient.Cl
 	}
 
 	queue, err := zd.GetQueue(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
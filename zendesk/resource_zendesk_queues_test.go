@@ -0,0 +1,70 @@
+package zendesk
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+func TestMarshalQueueRoundTrip(t *testing.T) {
+	queue := newClient.Queue{
+		ID:          1,
+		Name:        "VIP",
+		Description: "Urgent tickets of VIP customers",
+		Definition: newClient.QueueDefinition{
+			All: []newClient.QueueCondition{
+				{Field: "priority", Operator: "is", Value: "urgent"},
+				{Field: "current_tags", Operator: "includes", Value: "vip"},
+			},
+			Any: []newClient.QueueCondition{
+				{Field: "brand_id", Operator: "is", Value: "5"},
+			},
+		},
+		Priority:        2,
+		PrimaryGroups:   []int64{10, 11},
+		SecondaryGroups: []int64{12},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceZendeskQueues().Schema, map[string]interface{}{})
+	d.SetId("1")
+	if err := marshalQueue(queue, d); err != nil {
+		t.Fatalf("marshalQueue returned an error: %v", err)
+	}
+
+	result, err := unmarshalQueue(d)
+	if err != nil {
+		t.Fatalf("unmarshalQueue returned an error: %v", err)
+	}
+
+	sortQueueConditions := func(conditions []newClient.QueueCondition) {
+		sort.Slice(conditions, func(i, j int) bool { return conditions[i].Field < conditions[j].Field })
+	}
+	sortQueueConditions(queue.Definition.All)
+	sortQueueConditions(result.Definition.All)
+	sort.Slice(result.PrimaryGroups, func(i, j int) bool { return result.PrimaryGroups[i] < result.PrimaryGroups[j] })
+
+	if !reflect.DeepEqual(result, queue) {
+		t.Fatalf("queue did not survive a round trip: %+v. should have been %+v", result, queue)
+	}
+}
+
+func TestUnmarshalQueueWithoutConditions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceZendeskQueues().Schema, map[string]interface{}{
+		"name":           "Overflow",
+		"primary_groups": []interface{}{10},
+	})
+
+	queue, err := unmarshalQueue(d)
+	if err != nil {
+		t.Fatalf("unmarshalQueue returned an error: %v", err)
+	}
+	if queue.Definition.All != nil || queue.Definition.Any != nil {
+		t.Fatalf("queue had conditions %+v. should have had none", queue.Definition)
+	}
+	if !reflect.DeepEqual(queue.PrimaryGroups, []int64{10}) {
+		t.Fatalf("queue had primary groups %v. should have been [10]", queue.PrimaryGroups)
+	}
+}
@@ -33,6 +33,7 @@ func resourceZendeskSLAPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: validateConditionsDiff,
 
 		Schema: map[string]*schema.Schema{
 			"title": {
@@ -261,6 +262,9 @@ func readSLAPolicy(ctx context.Context, d identifiableGetterSetter, zd client.SL
 	}
 
 	slaPolicy, err := zd.GetSLAPolicy(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -322,14 +326,16 @@ func slaPolicyFilterSchema(desc string) *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"field": {
-					Description: "The name of a ticket field.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "The name of a ticket field. Custom fields are written as `custom_fields_<id>`.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionField(conditionSLAPolicy),
 				},
 				"operator": {
-					Description: "A comparison operator.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "A comparison operator.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionOperator(),
 				},
 				"value": {
 					Description: "The value of a ticket field.",
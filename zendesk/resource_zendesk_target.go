@@ -162,9 +162,9 @@ func unmarshalTarget(d identifiableGetterSetter) (client.Target, error) {
 		target.ID = id
 	}
 
-	// if v, ok := d.GetOk("url"); ok {
-	// 	target.URL = v.(string)
-	// }
+	if v, ok := d.GetOk("url"); ok {
+		target.URL = v.(string)
+	}
 
 	if v, ok := d.GetOk("type"); ok {
 		target.Type = v.(string)
@@ -246,6 +246,9 @@ func readTarget(ctx context.Context, d identifiableGetterSetter, zd client.Targe
 	}
 
 	target, err := zd.GetTarget(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -271,6 +274,9 @@ func updateTarget(ctx context.Context, d identifiableGetterSetter, zd client.Tar
 		return diag.FromErr(err)
 	}
 
+	// url is read-only, don't send back the value read from the API
+	target.URL = ""
+
 	// ActualAPI request
 	target, err = zd.UpdateTarget(ctx, id, target)
 	if err != nil {
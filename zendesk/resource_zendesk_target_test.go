@@ -187,11 +187,19 @@ func TestUpdateTarget(t *testing.T) {
 
 	m := mock.NewClient(ctrl)
 	i := &identifiableMapGetterSetter{
-		id:              "12345",
-		mapGetterSetter: make(mapGetterSetter),
+		id: "12345",
+		mapGetterSetter: mapGetterSetter{
+			"url":   "https://example.zendesk.com/api/v2/targets/12345.json",
+			"title": "target :: email :: john.doe@example.com",
+		},
 	}
 
-	m.EXPECT().UpdateTarget(Any(), Eq(int64(12345)), Any()).Return(zendesk.Target{}, nil)
+	m.EXPECT().UpdateTarget(Any(), Eq(int64(12345)), Any()).DoAndReturn(func(ctx context.Context, id int64, target zendesk.Target) (zendesk.Target, error) {
+		if target.URL != "" {
+			t.Fatalf("updateTarget sent the read-only url %s", target.URL)
+		}
+		return target, nil
+	})
 	if diags := updateTarget(context.Background(), i, m); len(diags) != 0 {
 		t.Fatalf("updateTarget returned an error: %v", diags)
 	}
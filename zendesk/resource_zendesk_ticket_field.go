@@ -20,9 +20,11 @@ func resourceZendeskTicketField() *schema.Resource {
 		ReadContext:   resourceZendeskTicketFieldRead,
 		UpdateContext: resourceZendeskTicketFieldUpdate,
 		DeleteContext: resourceZendeskTicketFieldDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importByAttribute("/ticket_fields.json", "ticket_fields",
+			func(t client.TicketField) int64 { return t.ID },
+			map[string]func(client.TicketField) string{
+				"title": func(t client.TicketField) string { return t.Title },
+			}),
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -400,6 +402,9 @@ func readTicketField(ctx context.Context, d identifiableGetterSetter, zd client.
 	}
 
 	field, err := zd.GetTicketField(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -85,6 +85,24 @@ func TestReadTicketField(t *testing.T) {
 	}
 }
 
+func TestReadTicketFieldNotFound(t *testing.T) {
+	ctrl := NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := newIdentifiableGetterSetter()
+	i.SetId("12345")
+
+	m.EXPECT().GetTicketField(Any(), Eq(int64(12345))).Return(zendesk.TicketField{}, newZendeskError(http.StatusNotFound))
+	if diags := readTicketField(context.Background(), i, m); len(diags) != 0 {
+		t.Fatalf("readTicketField returned an error for a deleted ticket field: %v", diags)
+	}
+
+	if v := i.Id(); v != "" {
+		t.Fatalf("readTicketField did not remove the ticket field from state. Id was %s", v)
+	}
+}
+
 func TestDeleteTicketField(t *testing.T) {
 	ctrl := NewController(t)
 	defer ctrl.Finish()
@@ -3,8 +3,11 @@ package zendesk
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
@@ -17,23 +20,78 @@ func resourceZendeskTicketForm() *schema.Resource {
 		Description: "Provides a ticket form resource.",
 		CreateContext: func(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
 			zd := i.(*newClient.Client)
-			return createTicketForm(ctx, data, zd)
+			err := applyDisplayNameVariants(ctx, data, zd)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			diags := createTicketForm(ctx, data, zd)
+			if diags.HasError() {
+				// nothing keeps track of the display names of a form which wasn't created
+				err = deleteDisplayNameItem(ctx, zd, int64(data.Get("display_name_dynamic_content_id").(int)))
+				if err != nil {
+					diags = append(diags, diag.FromErr(err)...)
+				}
+			}
+			return diags
 		},
 		ReadContext: func(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
 			zd := i.(*newClient.Client)
-			return readTicketForm(ctx, data, zd)
+			diags := readTicketForm(ctx, data, zd)
+			if diags.HasError() || data.Id() == "" {
+				return diags
+			}
+
+			err := readDisplayNameVariants(ctx, data, zd)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return diags
 		},
 		UpdateContext: func(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
 			zd := i.(*newClient.Client)
-			return updateTicketForm(ctx, data, zd)
+			err := applyDisplayNameVariants(ctx, data, zd)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			removed, err := detachDisplayNameVariants(data)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			diags := updateTicketForm(ctx, data, zd)
+			if diags.HasError() {
+				return diags
+			}
+
+			err = deleteDisplayNameItem(ctx, zd, removed)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return diags
 		},
 		DeleteContext: func(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
 			zd := i.(*newClient.Client)
-			return deleteTicketForm(ctx, data, zd)
+			diags := deleteTicketForm(ctx, data, zd)
+			if diags.HasError() {
+				return diags
+			}
+
+			err := deleteDisplayNameItem(ctx, zd, int64(data.Get("display_name_dynamic_content_id").(int)))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return diags
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			planClonedTicketFormAttributes,
+			validateTicketFormConditionsDiff,
+			validateDisplayNameVariantsDiff,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -50,11 +108,25 @@ func resourceZendeskTicketForm() *schema.Resource {
 				Description: "The name of the form that is displayed to an end user.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
+			},
+			"display_name_variant": displayNameVariantSchema(),
+			"display_name_dynamic_content_id": {
+				Description: "ID of the dynamic content item holding `display_name_variant`.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"source_form_id": {
+				Description: "ID of a ticket form to clone when this form is created, for example from another brand. The fields and conditions of the source which aren't set in this resource are kept, and managed once they are set.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
 			},
 			"position": {
-				Description: "The position of this form among other forms in the account, i.e. dropdown.",
+				Description: "The position of this form among other forms in the account, i.e. dropdown. Leave it unset when the form is listed in `zendesk_ticket_form_order`, since both would set the position and undo each other on every apply.",
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 			},
 			"active": {
 				Description: "If the form is set as active.",
@@ -79,6 +151,7 @@ func resourceZendeskTicketForm() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 				Optional: true,
+				Computed: true,
 			},
 			"in_all_brands": {
 				Description: "Is the form available for use in all brands on this account.",
@@ -87,78 +160,336 @@ func resourceZendeskTicketForm() *schema.Resource {
 				Default:     true,
 			},
 			"restricted_brand_ids": {
-				Description: "ids of all brands that this ticket form is restricted to.",
+				Description: "ids of all brands that this ticket form is restricted to, when in_all_brands is false.",
 				Type:        schema.TypeSet,
 				Elem: &schema.Schema{
 					Type: schema.TypeInt,
 				},
+				Optional: true,
 				Computed: true,
 			},
-			"agent_conditions": {
-				Description: "Array of condition sets for agent workspaces",
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"parent_field_id": {
-							Description: "ID of the parent field",
-							Type:        schema.TypeInt,
-							Required:    true,
-						},
-						"value": {
-							Description: "value",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"child_fields": {
-							Description: "Child Fields",
-							Type:        schema.TypeSet,
-							Required:    true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"id": {
-										Description: "",
-										Type:        schema.TypeInt,
-										Required:    true,
-									},
-									"is_required": {
-										Description: "",
-										Type:        schema.TypeBool,
-										Required:    true,
-									},
-									"required_on_statuses": {
-										Description: "",
-										Type:        schema.TypeSet,
-										Required:    true,
-										MaxItems:    1, // Ensures only one element is allowed
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"type": {
-													Description: "",
-													Required:    true,
-													Type:        schema.TypeString,
-												},
-												"statuses": {
-													Description: "",
-													Optional:    true,
-													Type:        schema.TypeSet,
-													Elem: &schema.Schema{
-														Type: schema.TypeString,
-													},
-												},
-											},
-										},
-									},
-								},
-							},
+			"agent_conditions":    ticketFormConditionsSchema("Array of condition sets for agent workspaces", true),
+			"end_user_conditions": ticketFormConditionsSchema("Array of condition sets for end users in the Help Center and web widget", false),
+		},
+	}
+}
+
+// ticketFormConditionsSchema returns the schema of agent_conditions and end_user_conditions.
+// Only agent conditions make child fields required depending on the ticket status.
+func ticketFormConditionsSchema(desc string, requiredOnStatuses bool) *schema.Schema {
+	childFields := map[string]*schema.Schema{
+		"id": {
+			Description: "",
+			Type:        schema.TypeInt,
+			Required:    true,
+		},
+		"is_required": {
+			Description: "",
+			Type:        schema.TypeBool,
+			Required:    true,
+		},
+	}
+	if requiredOnStatuses {
+		childFields["required_on_statuses"] = &schema.Schema{
+			Description: "",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MaxItems:    1, // Ensures only one element is allowed
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Description: "",
+						Required:    true,
+						Type:        schema.TypeString,
+					},
+					"statuses": {
+						Description: "",
+						Optional:    true,
+						Type:        schema.TypeSet,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
 						},
 					},
 				},
 			},
+		}
+	}
+
+	return &schema.Schema{
+		Description: desc,
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"parent_field_id": {
+					Description: "ID of the parent field",
+					Type:        schema.TypeInt,
+					Required:    true,
+				},
+				"value": {
+					Description: "The value of the parent field which shows the child fields. For fields with options, it must be the value of one of the options.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"child_fields": {
+					Description: "Child Fields",
+					Type:        schema.TypeSet,
+					Required:    true,
+					Elem: &schema.Resource{
+						Schema: childFields,
+					},
+				},
+			},
 		},
 	}
 }
 
+// clonedTicketFormAttributes are copied from the source form of a form created with source_form_id
+var clonedTicketFormAttributes = []string{"ticket_field_ids", "agent_conditions", "end_user_conditions"}
+
+// planClonedTicketFormAttributes plans the attributes a clone copies from its
+// source when they aren't configured. A form with source_form_id keeps the
+// values it has, any other form clears them.
+func planClonedTicketFormAttributes(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	if !config.GetAttr("source_form_id").IsNull() {
+		// the attributes are computed, so they keep their prior values
+		return nil
+	}
+
+	for _, key := range clonedTicketFormAttributes {
+		v := config.GetAttr(key)
+		if !v.IsKnown() {
+			continue
+		}
+		// conditions are blocks, which are an empty set when there are none
+		if v.IsNull() || (key != "ticket_field_ids" && v.LengthInt() == 0) {
+			err := d.SetNew(key, []interface{}{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// expandTicketFormConditions parses agent_conditions or end_user_conditions
+func expandTicketFormConditions(v interface{}) []models.TicketFormCondition {
+	var conditions []models.TicketFormCondition
+
+	for _, c := range v.(*schema.Set).List() {
+		block := c.(map[string]interface{})
+		condition := models.TicketFormCondition{
+			ParentFieldId: int64(block["parent_field_id"].(int)),
+			Value:         block["value"].(string),
+		}
+
+		for _, f := range block["child_fields"].(*schema.Set).List() {
+			childBlock := f.(map[string]interface{})
+			childField := models.ChildFields{
+				Id:         int64(childBlock["id"].(int)),
+				IsRequired: childBlock["is_required"].(bool),
+			}
+
+			// end user conditions don't have required_on_statuses
+			if set, ok := childBlock["required_on_statuses"].(*schema.Set); ok && set.Len() > 0 {
+				requiredOnStatuses := set.List()[0].(map[string]interface{})
+
+				var statuses []string
+				for _, status := range requiredOnStatuses["statuses"].(*schema.Set).List() {
+					statuses = append(statuses, status.(string))
+				}
+
+				childField.RequiredOnStatuses = &models.RequiredOnStatuses{
+					Type:     requiredOnStatuses["type"].(string),
+					Statuses: statuses,
+				}
+			}
+			condition.ChildFields = append(condition.ChildFields, childField)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions
+}
+
+// flattenTicketFormConditions encodes agent or end user conditions for the resource data
+func flattenTicketFormConditions(conditions []models.TicketFormCondition) []interface{} {
+	var conditionsList []interface{}
+
+	for _, condition := range conditions {
+		var childFieldsList []interface{}
+		for _, childField := range condition.ChildFields {
+			childFieldMap := map[string]interface{}{
+				"id":          childField.Id,
+				"is_required": childField.IsRequired,
+			}
+			if childField.RequiredOnStatuses != nil {
+				childFieldMap["required_on_statuses"] = []interface{}{
+					map[string]interface{}{
+						"type":     childField.RequiredOnStatuses.Type,
+						"statuses": childField.RequiredOnStatuses.Statuses,
+					},
+				}
+			}
+			childFieldsList = append(childFieldsList, childFieldMap)
+		}
+
+		conditionsList = append(conditionsList, map[string]interface{}{
+			"value":           condition.Value,
+			"parent_field_id": condition.ParentFieldId,
+			"child_fields":    childFieldsList,
+		})
+	}
+
+	return conditionsList
+}
+
+// validateTicketFormConditions checks that the fields of the conditions are in
+// the form and that child fields don't show their own parents. fieldIDs is nil
+// when the fields of the form aren't known yet. Unknown IDs are 0 and aren't
+// checked. Values aren't checked against the options of the parent field, which
+// may be added in the same apply.
+func validateTicketFormConditions(fieldIDs []int64, conditions []models.TicketFormCondition) error {
+	inForm := map[int64]bool{}
+	for _, id := range fieldIDs {
+		inForm[id] = true
+	}
+
+	children := map[int64][]int64{}
+	for _, condition := range conditions {
+		parent := condition.ParentFieldId
+		if parent == 0 {
+			continue
+		}
+
+		if fieldIDs != nil && !inForm[parent] {
+			return fmt.Errorf("parent field %d is not in ticket_field_ids", parent)
+		}
+
+		for _, child := range condition.ChildFields {
+			if child.Id == 0 {
+				continue
+			}
+			if fieldIDs != nil && !inForm[child.Id] {
+				return fmt.Errorf("child field %d of parent field %d is not in ticket_field_ids", child.Id, parent)
+			}
+			children[parent] = append(children[parent], child.Id)
+		}
+	}
+
+	if cycle := ticketFormConditionCycle(children); cycle != nil {
+		path := make([]string, len(cycle))
+		for i, id := range cycle {
+			path[i] = fmt.Sprintf("%d", id)
+		}
+		return fmt.Errorf("conditions form a cycle: %s", strings.Join(path, " -> "))
+	}
+
+	return nil
+}
+
+// ticketFormConditionCycle returns the fields of a cycle of parents and children, or nil
+func ticketFormConditionCycle(children map[int64][]int64) []int64 {
+	parents := make([]int64, 0, len(children))
+	for parent := range children {
+		parents = append(parents, parent)
+	}
+	sort.Slice(parents, func(i, j int) bool { return parents[i] < parents[j] })
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[int64]int{}
+	var path []int64
+
+	var visit func(id int64) []int64
+	visit = func(id int64) []int64 {
+		switch state[id] {
+		case visiting:
+			for i, p := range path {
+				if p == id {
+					return append(append([]int64{}, path[i:]...), id)
+				}
+			}
+		case done:
+			return nil
+		}
+
+		state[id] = visiting
+		path = append(path, id)
+		for _, child := range children[id] {
+			if cycle := visit(child); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+
+		return nil
+	}
+
+	for _, parent := range parents {
+		if cycle := visit(parent); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// validateTicketFormConditionsDiff checks agent_conditions and end_user_conditions
+// against ticket_field_ids, which Zendesk would otherwise only reject when applying.
+func validateTicketFormConditionsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// a clone gets the fields of its source unless they are configured
+	_, cloned := d.GetOk("source_form_id")
+	cloned = cloned && !ticketFormAttributeConfigured(d, "ticket_field_ids")
+
+	var fieldIDs []int64
+	if d.NewValueKnown("ticket_field_ids") && !cloned {
+		fieldIDs = []int64{}
+		for _, v := range d.Get("ticket_field_ids").([]interface{}) {
+			id, _ := v.(int)
+			// IDs of fields created in the same apply are unknown
+			if id == 0 {
+				fieldIDs = nil
+				break
+			}
+			fieldIDs = append(fieldIDs, int64(id))
+		}
+	}
+
+	for _, key := range []string{"agent_conditions", "end_user_conditions"} {
+		v, ok := d.GetOk(key)
+		if !ok {
+			continue
+		}
+
+		err := validateTicketFormConditions(fieldIDs, expandTicketFormConditions(v))
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// ticketFormAttributeConfigured reports whether key is set in the configuration
+// of the form. It is false when the configuration isn't available.
+func ticketFormAttributeConfigured(d *schema.ResourceDiff, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	return !config.GetAttr(key).IsNull()
+}
+
 // unmarshalTicketField parses the provided ResourceData and returns a ticket field
 func unmarshalTicketForm(d identifiableGetterSetter) (models.TicketForm, error) {
 	tf := models.TicketForm{}
@@ -215,46 +546,19 @@ func unmarshalTicketForm(d identifiableGetterSetter) (models.TicketForm, error)
 	if v, ok := d.GetOk("restricted_brand_ids"); ok {
 		brandIDs := v.(*schema.Set).List()
 		for _, id := range brandIDs {
-			tf.TicketFieldIDs = append(tf.RestrictedBrandIDs, int64(id.(int)))
+			tf.RestrictedBrandIDs = append(tf.RestrictedBrandIDs, int64(id.(int)))
 		}
 	}
 
+	// empty lists are sent, so that removing all conditions clears them in Zendesk
+	tf.AgentConditions = []models.TicketFormCondition{}
 	if v, ok := d.GetOk("agent_conditions"); ok {
-		agent_conditions := v.(*schema.Set).List()
-		for _, agent_condition := range agent_conditions {
-			AgentCondition := models.AgentCondition{}
-			AgentCondition.Value = agent_condition.(map[string]interface{})["value"].(string)
-			AgentCondition.ParentFieldId = int64(agent_condition.(map[string]interface{})["parent_field_id"].(int))
-			ChildFields := agent_condition.(map[string]interface{})["child_fields"].(*schema.Set).List()
-			// for _, child_field := range agent_condition.(map[string]interface{})["child_fields"].([]interface{}) {
-			for _, child_field := range ChildFields {
-				ChildField := models.ChildFields{}
-				ChildField.Id = int64(child_field.(map[string]interface{})["id"].(int))
-				ChildField.IsRequired = child_field.(map[string]interface{})["is_required"].(bool)
-
-				required_on_statuses := child_field.(map[string]interface{})["required_on_statuses"].(*schema.Set).List()
-
-				if len(required_on_statuses) > 0 {
-					// Extract the first element from required_on_statuses, assuming it's a slice
-					statusesInterface := required_on_statuses[0].(map[string]interface{})["statuses"].(*schema.Set).List()
-
-					// Initialize a slice to hold the converted statuses
-					statuses := make([]string, len(statusesInterface))
-
-					// Loop through the []interface{} and convert each item to a string
-					for i, v := range statusesInterface {
-						statuses[i] = v.(string) // Type assert to string
-					}
-
-					ChildField.RequiredOnStatuses = models.RequiredOnStatuses{
-						Type:     required_on_statuses[0].(map[string]interface{})["type"].(string),
-						Statuses: statuses,
-					}
-				}
-				AgentCondition.ChildFields = append(AgentCondition.ChildFields, ChildField)
-			}
-			tf.AgentConditions = append(tf.AgentConditions, AgentCondition)
-		}
+		tf.AgentConditions = expandTicketFormConditions(v)
+	}
+
+	tf.EndUserConditions = []models.TicketFormCondition{}
+	if v, ok := d.GetOk("end_user_conditions"); ok {
+		tf.EndUserConditions = expandTicketFormConditions(v)
 	}
 
 	return tf, nil
@@ -262,39 +566,16 @@ func unmarshalTicketForm(d identifiableGetterSetter) (models.TicketForm, error)
 
 // marshalTicketField encodes the provided form into the provided resource data
 func marshalTicketForm(f models.TicketForm, d identifiableGetterSetter) error {
-
-	var agentConditionsList []interface{}
-
-	for _, agentCondition := range f.AgentConditions {
-		agentConditionMap := map[string]interface{}{
-			"value":           agentCondition.Value,
-			"parent_field_id": agentCondition.ParentFieldId,
-		}
-
-		var childFieldsList []interface{}
-		for _, childField := range agentCondition.ChildFields {
-
-			var requiredStatuses []interface{}
-			requiredStatuses = append(requiredStatuses, map[string]interface{}{
-				"type":     childField.RequiredOnStatuses.Type,
-				"statuses": childField.RequiredOnStatuses.Statuses,
-			})
-
-			childFieldMap := map[string]interface{}{
-				"id":                   childField.Id,
-				"is_required":          childField.IsRequired,
-				"required_on_statuses": requiredStatuses,
-			}
-			childFieldsList = append(childFieldsList, childFieldMap)
-		}
-		agentConditionMap["child_fields"] = childFieldsList
-		agentConditionsList = append(agentConditionsList, agentConditionMap)
+	// display_name is rendered from dynamic content, raw_display_name has the placeholder
+	displayName := f.DisplayName
+	if f.RawDisplayName != "" {
+		displayName = f.RawDisplayName
 	}
 
 	fields := map[string]interface{}{
 		"url":                  f.URL,
 		"name":                 f.Name,
-		"display_name":         f.DisplayName,
+		"display_name":         displayName,
 		"position":             f.Position,
 		"active":               f.Active,
 		"end_user_visible":     f.EndUserVisible,
@@ -302,7 +583,8 @@ func marshalTicketForm(f models.TicketForm, d identifiableGetterSetter) error {
 		"ticket_field_ids":     f.TicketFieldIDs,
 		"in_all_brands":        f.InAllBrands,
 		"restricted_brand_ids": f.RestrictedBrandIDs,
-		"agent_conditions":     agentConditionsList,
+		"agent_conditions":     flattenTicketFormConditions(f.AgentConditions),
+		"end_user_conditions":  flattenTicketFormConditions(f.EndUserConditions),
 	}
 
 	err := setSchemaFields(d, fields)
@@ -322,9 +604,34 @@ func createTicketForm(ctx context.Context, d identifiableGetterSetter, zd newCli
 	}
 
 	// Actual API request
-	tf, err = zd.CreateTicketForm(ctx, tf)
-	if err != nil {
-		return diag.FromErr(err)
+	if v, ok := d.GetOk("source_form_id"); ok {
+		clone, err := zd.CloneTicketForm(ctx, int64(v.(int)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// keep what the clone copied from the source unless it is configured
+		if _, ok := d.GetOk("ticket_field_ids"); !ok {
+			tf.TicketFieldIDs = clone.TicketFieldIDs
+		}
+		if _, ok := d.GetOk("agent_conditions"); !ok && clone.AgentConditions != nil {
+			tf.AgentConditions = clone.AgentConditions
+		}
+		if _, ok := d.GetOk("end_user_conditions"); !ok && clone.EndUserConditions != nil {
+			tf.EndUserConditions = clone.EndUserConditions
+		}
+
+		tf, err = zd.UpdateTicketForm(ctx, clone.ID, tf)
+		if err != nil {
+			// the clone isn't in the state yet, so it would be left behind
+			_ = zd.DeleteTicketForm(ctx, clone.ID)
+			return diag.Errorf("could not update clone %d of ticket form %d: %v", clone.ID, v, err)
+		}
+	} else {
+		tf, err = zd.CreateTicketForm(ctx, tf)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	// Patch from created resource
@@ -347,6 +654,9 @@ func readTicketForm(ctx context.Context, d identifiableGetterSetter, zd newClien
 	}
 
 	tf, err := zd.GetTicketForm(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
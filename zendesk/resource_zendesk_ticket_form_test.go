@@ -2,10 +2,16 @@ package zendesk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/nukosuke/go-zendesk/zendesk"
 	"github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
@@ -18,6 +24,7 @@ type mockTicketFormAPI struct {
 	getTicketForm    func(ctx context.Context, id int64) (models.TicketForm, error)
 	deleteTicketForm func(ctx context.Context, id int64) error
 	updateTicketForm func(ctx context.Context, id int64, form models.TicketForm) (models.TicketForm, error)
+	cloneTicketForm  func(ctx context.Context, id int64) (models.TicketForm, error)
 }
 
 func (m *mockTicketFormAPI) CreateTicketForm(ctx context.Context, ticketForm models.TicketForm) (models.TicketForm, error) {
@@ -48,6 +55,13 @@ func (m *mockTicketFormAPI) UpdateTicketForm(ctx context.Context, id int64, form
 	return models.TicketForm{}, nil
 }
 
+func (m *mockTicketFormAPI) CloneTicketForm(ctx context.Context, id int64) (models.TicketForm, error) {
+	if m.cloneTicketForm != nil {
+		return m.cloneTicketForm(ctx, id)
+	}
+	return models.TicketForm{}, nil
+}
+
 func (m *mockTicketFormAPI) GetTicketForms(ctx context.Context, options *zendesk.TicketFormListOptions) ([]models.TicketForm, zendesk.Page, error) {
 	return nil, zendesk.Page{}, nil
 }
@@ -145,6 +159,27 @@ func TestUnmarshalTicketForm(t *testing.T) {
 	}
 }
 
+func TestUnmarshalTicketFormRestrictedBrandIDs(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, map[string]interface{}{
+		"name":                 "Snowboard Problem",
+		"in_all_brands":        false,
+		"ticket_field_ids":     []interface{}{1, 2},
+		"restricted_brand_ids": []interface{}{360001},
+	})
+
+	tf, err := unmarshalTicketForm(d)
+	if err != nil {
+		t.Fatalf("unmarshal returned an error: %v", err)
+	}
+
+	if !reflect.DeepEqual(tf.RestrictedBrandIDs, []int64{360001}) {
+		t.Fatalf("ticket form had restricted brand ids %v. should have been [360001]", tf.RestrictedBrandIDs)
+	}
+	if !reflect.DeepEqual(tf.TicketFieldIDs, []int64{1, 2}) {
+		t.Fatalf("ticket form had ticket field ids %v. should have been [1 2]", tf.TicketFieldIDs)
+	}
+}
+
 func testTicketFormDestroyed(s *terraform.State) error {
 	client := testAccProvider.Meta().(client.TicketFormAPI)
 
@@ -198,3 +233,368 @@ func TestAccTicketFormExample(t *testing.T) {
 		},
 	})
 }
+
+func TestMarshalTicketFormConditions(t *testing.T) {
+	form := models.TicketForm{
+		ID:             47,
+		Name:           "Snowboard Problem",
+		TicketFieldIDs: []int64{1, 2, 3},
+		AgentConditions: []models.TicketFormCondition{{
+			ParentFieldId: 1,
+			Value:         "damaged",
+			ChildFields: []models.ChildFields{{
+				Id:         2,
+				IsRequired: true,
+				RequiredOnStatuses: &models.RequiredOnStatuses{
+					Type:     "SOME_STATUSES",
+					Statuses: []string{"solved"},
+				},
+			}},
+		}},
+		EndUserConditions: []models.TicketFormCondition{{
+			ParentFieldId: 1,
+			Value:         "damaged",
+			ChildFields:   []models.ChildFields{{Id: 3, IsRequired: true}},
+		}},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, map[string]interface{}{})
+	d.SetId("47")
+	if err := marshalTicketForm(form, d); err != nil {
+		t.Fatalf("marshalTicketForm returned an error: %v", err)
+	}
+
+	result, err := unmarshalTicketForm(d)
+	if err != nil {
+		t.Fatalf("unmarshalTicketForm returned an error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result.AgentConditions, form.AgentConditions) {
+		t.Fatalf("agent conditions did not survive a round trip: %+v. should have been %+v", result.AgentConditions, form.AgentConditions)
+	}
+	if !reflect.DeepEqual(result.EndUserConditions, form.EndUserConditions) {
+		t.Fatalf("end user conditions did not survive a round trip: %+v. should have been %+v", result.EndUserConditions, form.EndUserConditions)
+	}
+}
+
+func TestTicketFormEndUserConditionsJSON(t *testing.T) {
+	form := models.TicketForm{
+		Name: "Snowboard Problem",
+		EndUserConditions: []models.TicketFormCondition{{
+			ParentFieldId: 1,
+			Value:         "damaged",
+			ChildFields:   []models.ChildFields{{Id: 3, IsRequired: false}},
+		}},
+	}
+
+	b, err := json.Marshal(form)
+	if err != nil {
+		t.Fatalf("could not marshal ticket form: %v", err)
+	}
+	if strings.Contains(string(b), "required_on_statuses") {
+		t.Fatalf("end user conditions should not have required_on_statuses: %s", b)
+	}
+	if !strings.Contains(string(b), `"end_user_conditions":[{"parent_field_id":1,"value":"damaged","child_fields":[{"id":3,"is_required":false}]}]`) {
+		t.Fatalf("ticket form did not contain the end user conditions: %s", b)
+	}
+}
+
+func TestUpdateTicketFormClearsConditions(t *testing.T) {
+	var body []byte
+	m := &mockTicketFormAPI{
+		updateTicketForm: func(ctx context.Context, id int64, form models.TicketForm) (models.TicketForm, error) {
+			var err error
+			if body, err = json.Marshal(form); err != nil {
+				t.Fatalf("could not marshal ticket form: %v", err)
+			}
+			return form, nil
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, map[string]interface{}{
+		"name": "Snowboard Problem",
+	})
+	d.SetId("47")
+	if diags := updateTicketForm(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("updateTicketForm returned an error: %v", diags)
+	}
+
+	for _, key := range []string{`"agent_conditions":[]`, `"end_user_conditions":[]`} {
+		if !strings.Contains(string(body), key) {
+			t.Fatalf("ticket form without conditions should have sent %s to clear them: %s", key, body)
+		}
+	}
+}
+
+func TestValidateTicketFormConditions(t *testing.T) {
+	condition := func(parent int64, value string, children ...int64) models.TicketFormCondition {
+		c := models.TicketFormCondition{ParentFieldId: parent, Value: value}
+		for _, child := range children {
+			c.ChildFields = append(c.ChildFields, models.ChildFields{Id: child})
+		}
+		return c
+	}
+	cases := []struct {
+		name       string
+		fieldIDs   []int64
+		conditions []models.TicketFormCondition
+		err        string
+	}{
+		{"valid", []int64{1, 2, 3}, []models.TicketFormCondition{condition(1, "damaged", 2), condition(2, "yes", 3)}, ""},
+		{"parent not in form", []int64{2}, []models.TicketFormCondition{condition(1, "damaged", 2)}, "parent field 1 is not in ticket_field_ids"},
+		{"child not in form", []int64{1}, []models.TicketFormCondition{condition(1, "damaged", 2)}, "child field 2 of parent field 1 is not in ticket_field_ids"},
+		{"unknown field ids", nil, []models.TicketFormCondition{condition(1, "damaged", 2)}, ""},
+		{"unknown child", []int64{1}, []models.TicketFormCondition{condition(1, "damaged", 0)}, ""},
+		{"option added in the same apply", []int64{1, 2}, []models.TicketFormCondition{condition(1, "stolen", 2)}, ""},
+		{"self reference", []int64{1}, []models.TicketFormCondition{condition(1, "damaged", 1)}, "conditions form a cycle: 1 -> 1"},
+		{"cycle", []int64{1, 2, 3}, []models.TicketFormCondition{
+			condition(1, "damaged", 2),
+			condition(2, "yes", 3),
+			condition(3, "no", 1),
+		}, "conditions form a cycle: 1 -> 2 -> 3 -> 1"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateTicketFormConditions(c.fieldIDs, c.conditions)
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("validateTicketFormConditions returned an error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.err {
+				t.Fatalf("validateTicketFormConditions returned %v. should have been %s", err, c.err)
+			}
+		})
+	}
+}
+
+func TestTicketFormConditionsDiff(t *testing.T) {
+	config := func(fieldIDs ...interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":             "Snowboard Problem",
+			"ticket_field_ids": fieldIDs,
+			"end_user_conditions": []interface{}{
+				map[string]interface{}{
+					"parent_field_id": 1,
+					"value":           "damaged",
+					"child_fields": []interface{}{
+						map[string]interface{}{"id": 2, "is_required": true},
+					},
+				},
+			},
+		})
+	}
+
+	r := resourceZendeskTicketForm()
+	if _, err := r.Diff(context.Background(), nil, config(1, 2), nil); err != nil {
+		t.Fatalf("Diff returned an error for a valid form: %v", err)
+	}
+
+	_, err := r.Diff(context.Background(), nil, config(1), nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid end_user_conditions: child field 2 of parent field 1 is not in ticket_field_ids") {
+		t.Fatalf("Diff returned %v. should have rejected a child field missing from the form", err)
+	}
+}
+
+// diffTicketForm plans the change from the form in state to raw, passing raw
+// to CustomizeDiff as Terraform does
+func diffTicketForm(t *testing.T, state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceDiff {
+	r := resourceZendeskTicketForm()
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("could not marshal config: %v", err)
+	}
+	config, err := ctyjson.Unmarshal(b, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("could not convert config %s: %v", b, err)
+	}
+
+	if state == nil {
+		state = &terraform.InstanceState{}
+	}
+	state.RawConfig = config
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("Diff returned an error: %v", err)
+	}
+	return diff
+}
+
+// ticketFormState returns the state of a form read from Zendesk with the given attributes
+func ticketFormState(t *testing.T, raw map[string]interface{}) *terraform.InstanceState {
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, raw)
+	d.SetId("47")
+	return d.State()
+}
+
+var snowboardConditions = []interface{}{
+	map[string]interface{}{
+		"parent_field_id": 1,
+		"value":           "damaged",
+		"child_fields": []interface{}{
+			map[string]interface{}{"id": 2, "is_required": false},
+		},
+	},
+}
+
+func TestTicketFormRemovedConditionsDiff(t *testing.T) {
+	state := ticketFormState(t, map[string]interface{}{
+		"name":             "Snowboard Problem",
+		"ticket_field_ids": []interface{}{1, 2},
+		"agent_conditions": snowboardConditions,
+	})
+
+	diff := diffTicketForm(t, state, map[string]interface{}{
+		"name": "Snowboard Problem",
+	})
+	if diff == nil {
+		t.Fatal("Diff was empty. should have removed the fields and conditions from the form")
+	}
+
+	for _, key := range []string{"agent_conditions.#", "ticket_field_ids.#"} {
+		if attr, ok := diff.Attributes[key]; !ok || attr.New != "0" {
+			t.Fatalf("Diff had %s %+v. should have removed it from the form", key, attr)
+		}
+	}
+	diff = diffTicketForm(t, nil, map[string]interface{}{
+		"name": "Snowboard Problem",
+	})
+	if attr := diff.Attributes["ticket_field_ids.#"]; attr != nil && attr.NewComputed {
+		t.Fatal("Diff had unknown ticket_field_ids for a new form. should have been empty")
+	}
+}
+
+func TestClonedTicketFormConditionsDiff(t *testing.T) {
+	config := map[string]interface{}{
+		"name":                "Snowboard Problem (Brand B)",
+		"source_form_id":      46,
+		"end_user_conditions": snowboardConditions,
+	}
+
+	// the fields come with the clone
+	diffTicketForm(t, nil, config)
+	diffTicketForm(t, ticketFormState(t, map[string]interface{}{
+		"name":             "Snowboard Problem (Brand B)",
+		"source_form_id":   46,
+		"ticket_field_ids": []interface{}{5},
+	}), config)
+
+	r := resourceZendeskTicketForm()
+	state := &terraform.InstanceState{RawConfig: cty.NullVal(r.CoreConfigSchema().ImpliedType())}
+	if _, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil); err != nil {
+		t.Fatalf("Diff returned an error without the raw configuration: %v", err)
+	}
+}
+
+func TestClonedTicketFormDiff(t *testing.T) {
+	state := ticketFormState(t, map[string]interface{}{
+		"name":                "Snowboard Problem (Brand B)",
+		"source_form_id":      46,
+		"ticket_field_ids":    []interface{}{1, 2},
+		"end_user_conditions": snowboardConditions,
+	})
+
+	diff := diffTicketForm(t, state, map[string]interface{}{
+		"name":           "Snowboard Problem (Brand B)",
+		"source_form_id": 46,
+	})
+	if diff != nil {
+		for key, attr := range diff.Attributes {
+			if strings.HasPrefix(key, "ticket_field_ids") || strings.HasPrefix(key, "end_user_conditions") {
+				t.Fatalf("Diff had %s %+v. should have kept what the form copied from its source", key, attr)
+			}
+		}
+	}
+
+	// once configured, they are managed like on any other form
+	diff = diffTicketForm(t, state, map[string]interface{}{
+		"name":             "Snowboard Problem (Brand B)",
+		"source_form_id":   46,
+		"ticket_field_ids": []interface{}{1, 2, 3},
+	})
+	if attr, ok := diff.Attributes["ticket_field_ids.2"]; !ok || attr.New != "3" {
+		t.Fatalf("Diff had ticket_field_ids.2 %+v. should have added the configured field", attr)
+	}
+	if attr, ok := diff.Attributes["end_user_conditions.#"]; ok {
+		t.Fatalf("Diff had end_user_conditions.# %+v. should have kept the conditions copied from the source", attr)
+	}
+
+	diff = diffTicketForm(t, nil, map[string]interface{}{
+		"name":           "Snowboard Problem (Brand C)",
+		"source_form_id": 46,
+	})
+	if attr, ok := diff.Attributes["ticket_field_ids.#"]; !ok || !attr.NewComputed {
+		t.Fatalf("Diff had ticket_field_ids.# %+v. should have been known after cloning", attr)
+	}
+}
+
+func TestCreateTicketFormFromSource(t *testing.T) {
+	conditions := []models.TicketFormCondition{{
+		ParentFieldId: 1,
+		Value:         "damaged",
+		ChildFields:   []models.ChildFields{{Id: 2, IsRequired: true}},
+	}}
+
+	var updated []int64
+	var sent models.TicketForm
+	m := &mockTicketFormAPI{
+		cloneTicketForm: func(ctx context.Context, id int64) (models.TicketForm, error) {
+			if id != 47 {
+				t.Fatalf("cloned ticket form %d. should have been 47", id)
+			}
+			return models.TicketForm{
+				ID:                48,
+				Name:              "Copy of Snowboard Problem",
+				TicketFieldIDs:    []int64{1, 2},
+				EndUserConditions: conditions,
+			}, nil
+		},
+		updateTicketForm: func(ctx context.Context, id int64, form models.TicketForm) (models.TicketForm, error) {
+			updated = append(updated, id)
+			sent = form
+			form.ID = id
+			return form, nil
+		},
+		createTicketForm: func(ctx context.Context, form models.TicketForm) (models.TicketForm, error) {
+			t.Fatal("a ticket form with source_form_id should have been cloned instead of created")
+			return form, nil
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, map[string]interface{}{
+		"name":           "Snowboard Problem (Brand B)",
+		"source_form_id": 47,
+	})
+	if diags := createTicketForm(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("createTicketForm returned an error: %v", diags)
+	}
+
+	if d.Id() != "48" || !reflect.DeepEqual(updated, []int64{48}) {
+		t.Fatalf("ticket form had id %s after updating %v. should have been the clone 48", d.Id(), updated)
+	}
+	if !reflect.DeepEqual(sent.TicketFieldIDs, []int64{1, 2}) {
+		t.Fatalf("clone was updated with ticket field ids %v. should have kept the fields of the source", sent.TicketFieldIDs)
+	}
+	if !reflect.DeepEqual(sent.EndUserConditions, conditions) {
+		t.Fatalf("clone was updated with end user conditions %+v. should have kept the conditions of the source", sent.EndUserConditions)
+	}
+	if sent.AgentConditions == nil || len(sent.AgentConditions) != 0 {
+		t.Fatalf("clone was updated with agent conditions %+v. should have sent the empty conditions of the source", sent.AgentConditions)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, map[string]interface{}{
+		"name":             "Snowboard Problem (Brand C)",
+		"source_form_id":   47,
+		"ticket_field_ids": []interface{}{3},
+	})
+	if diags := createTicketForm(context.Background(), d, m); diags.HasError() {
+		t.Fatalf("createTicketForm returned an error: %v", diags)
+	}
+	if !reflect.DeepEqual(sent.TicketFieldIDs, []int64{3}) {
+		t.Fatalf("clone was updated with ticket field ids %v. should have been the configured [3]", sent.TicketFieldIDs)
+	}
+}
@@ -224,6 +224,9 @@ func readTicket(ctx context.Context, d identifiableGetterSetter, zd *newClient.C
 	}
 
 	ticket, err := zd.GetTicket(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
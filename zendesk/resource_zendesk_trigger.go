@@ -2,11 +2,10 @@ package zendesk
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	client "github.com/nukosuke/go-zendesk/zendesk"
 )
@@ -31,9 +30,16 @@ func resourceZendeskTrigger() *schema.Resource {
 			zd := i.(client.TriggerAPI)
 			return deleteTrigger(ctx, d, zd)
 		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importByAttribute("/triggers.json", "triggers",
+			func(t client.Trigger) int64 { return t.ID },
+			map[string]func(client.Trigger) string{
+				"title": func(t client.Trigger) string { return t.Title },
+			}),
+		CustomizeDiff: customdiff.All(
+			validateConditionsDiff,
+			validateActionsDiff,
+			lintLiquidDiff("action", webhookNotificationKey),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"title": {
@@ -53,32 +59,16 @@ func resourceZendeskTrigger() *schema.Resource {
 				Default:     true,
 			},
 			"position": {
-				Description: "Position of the trigger, determines the order they will execute in.",
+				Description: "Position of the trigger, determines the order they will execute in. Leave it unset when the trigger is listed in `zendesk_trigger_order`, since both would set the position and undo each other on every apply.",
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
 			},
 			// Both the "all" and "any" parameter are optional, but at least one of them must be supplied
-			"all": triggerConditionSchema("Logical AND. All the conditions must be met."),
-			"any": triggerConditionSchema("Logical OR. Any condition can be met."),
-			"action": {
-				Description: "What the trigger will do.",
-				Type:        schema.TypeSet,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"field": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Description: "Can be a single string value or a jsonencode'ed list",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
-				},
-				Required: true,
-			},
+			"all":                  triggerConditionSchema("Logical AND. All the conditions must be met."),
+			"any":                  triggerConditionSchema("Logical OR. Any condition can be met."),
+			"action":               actionSchema("What the trigger will do.", webhookNotificationKey),
+			webhookNotificationKey: webhookNotificationSchema("Webhooks the trigger calls. Each block is sent as a `notification_webhook` action."),
 			"description": {
 				Description: "The description of the trigger.",
 				Type:        schema.TypeString,
@@ -121,28 +111,17 @@ func marshalTrigger(trigger client.Trigger, d identifiableGetterSetter) error {
 	}
 	fields["any"] = anys
 
-	var actions []map[string]interface{}
+	var ruleActions []ruleAction
 	for _, action := range trigger.Actions {
+		ruleActions = append(ruleActions, ruleAction{Field: action.Field, Value: action.Value})
+	}
 
-		// If the trigger value is a string, leave it be
-		// If it's a list, marshal it to a string
-		var stringVal string
-		switch action.Value.(type) {
-		case []interface{}:
-			tmp, err := json.Marshal(action.Value)
-			if err != nil {
-				return fmt.Errorf("error decoding trigger action value: %s", err)
-			}
-			stringVal = string(tmp)
-		case string:
-			stringVal = action.Value.(string)
-		}
+	ruleActions, webhooks := flattenWebhookNotifications(ruleActions, d.Get("action"))
+	fields[webhookNotificationKey] = webhooks
 
-		m := map[string]interface{}{
-			"field": action.Field,
-			"value": stringVal,
-		}
-		actions = append(actions, m)
+	actions, err := flattenActions(ruleActions, d.Get("action"))
+	if err != nil {
+		return fmt.Errorf("error decoding trigger action value: %s", err)
 	}
 	fields["action"] = actions
 	return setSchemaFields(d, fields)
@@ -214,29 +193,29 @@ func unmarshalTrigger(d identifiableGetterSetter) (client.Trigger, error) {
 		trg.Conditions.Any = conditions
 	}
 
+	var ruleActions []ruleAction
 	if v, ok := d.GetOk("action"); ok {
-		triggerActions := v.(*schema.Set).List()
-		actions := []client.TriggerAction{}
-		for _, a := range triggerActions {
-			action, ok := a.(map[string]interface{})
-			if !ok {
-				return trg, fmt.Errorf("could not parse actions for trigger %v", trg)
-			}
+		actions, err := expandActions(v)
+		if err != nil {
+			return trg, fmt.Errorf("could not parse actions for trigger %v: %v", trg, err)
+		}
+		ruleActions = append(ruleActions, actions...)
+	}
 
-			// If the action value is a list, unmarshal it
-			var actionValue interface{}
-			if strings.HasPrefix(action["value"].(string), "[") {
-				err := json.Unmarshal([]byte(action["value"].(string)), &actionValue)
-				if err != nil {
-					return trg, fmt.Errorf("error unmarshalling trigger action value: %s", err)
-				}
-			} else {
-				actionValue = action["value"]
-			}
+	if v, ok := d.GetOk(webhookNotificationKey); ok {
+		webhooks, err := expandWebhookNotifications(v)
+		if err != nil {
+			return trg, fmt.Errorf("could not parse webhook notifications for trigger %v: %v", trg, err)
+		}
+		ruleActions = append(ruleActions, webhooks...)
+	}
 
+	if len(ruleActions) > 0 {
+		actions := []client.TriggerAction{}
+		for _, action := range ruleActions {
 			actions = append(actions, client.TriggerAction{
-				Field: action["field"].(string),
-				Value: actionValue,
+				Field: action.Field,
+				Value: action.Value,
 			})
 		}
 		trg.Actions = actions
@@ -277,6 +256,9 @@ func readTrigger(ctx context.Context, d identifiableGetterSetter, zd client.Trig
 	}
 
 	trigger, err := zd.GetTrigger(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -338,14 +320,16 @@ func triggerConditionSchema(desc string) *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"field": {
-					Description: "The name of a ticket field.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "The name of a ticket field. Custom fields are written as `custom_fields_<id>`.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionField(conditionTrigger),
 				},
 				"operator": {
-					Description: "A comparison operator.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "A comparison operator.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionOperator(),
 				},
 				"value": {
 					Description: "The value of a ticket field.",
@@ -87,7 +87,6 @@ func resourceZendeskTriggerCategoryCreate(ctx context.Context, d *schema.Resourc
 func createTriggerCategory(ctx context.Context, d identifiableGetterSetter, zd *newClient.Client) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	fmt.Println("Creating Trigger Category")
 	tf, err := unmarshalTriggerCategory(d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -123,6 +122,9 @@ func readTriggerCategory(ctx context.Context, d identifiableGetterSetter, zd *ne
 	}
 
 	triggerCategory, err := GetTriggerCategory(ctx, zd, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
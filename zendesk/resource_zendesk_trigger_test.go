@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/nukosuke/go-zendesk/zendesk"
 	"github.com/nukosuke/go-zendesk/zendesk/mock"
@@ -116,6 +117,24 @@ func TestReadTrigger(t *testing.T) {
 	}
 }
 
+func TestReadTriggerNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := newIdentifiableGetterSetter()
+	i.SetId("12345")
+
+	m.EXPECT().GetTrigger(gomock.Any(), gomock.Eq(int64(12345))).Return(zendesk.Trigger{}, newZendeskError(http.StatusNotFound))
+	if diags := readTrigger(context.Background(), i, m); len(diags) != 0 {
+		t.Fatalf("readTrigger returned an error for a deleted trigger: %v", diags)
+	}
+
+	if v := i.Id(); v != "" {
+		t.Fatalf("readTrigger did not remove the trigger from state. Id was %s", v)
+	}
+}
+
 func TestUpdateTrigger(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -199,3 +218,30 @@ func TestAccTriggerExample(t *testing.T) {
 		},
 	})
 }
+
+func TestTriggerCassette(t *testing.T) {
+	meta := testCassetteProvider(t, "trigger")
+
+	trigger := map[string]interface{}{
+		"title": "Escalate VIP",
+		"all": []interface{}{
+			map[string]interface{}{"field": "current_tags", "operator": "includes", "value": "vip"},
+		},
+		"action": []interface{}{
+			map[string]interface{}{"field": "priority", "value": "urgent"},
+			map[string]interface{}{"field": "set_tags", "value": "escalated"},
+		},
+	}
+	updated := map[string]interface{}{
+		"title":  "Escalate VIP customers",
+		"active": false,
+		"all":    trigger["all"],
+		"action": trigger["action"],
+	}
+
+	d := testCassetteLifecycle(t, meta, "zendesk_trigger", trigger, updated)
+
+	if v := d.Get("action").(*schema.Set); v.Len() != 2 {
+		t.Fatalf("trigger had actions %v. should have been %v", v, trigger["action"])
+	}
+}
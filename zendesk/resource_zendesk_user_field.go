@@ -20,9 +20,12 @@ func resourceZendeskUserField() *schema.Resource {
 		ReadContext:   resourceZendeskUserFieldRead,
 		UpdateContext: resourceZendeskUserFieldUpdate,
 		DeleteContext: resourceZendeskUserFieldDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importByAttribute("/user_fields.json", "user_fields",
+			func(u UserField) int64 { return u.ID },
+			map[string]func(UserField) string{
+				"key":   func(u UserField) string { return u.Key },
+				"title": func(u UserField) string { return u.Title },
+			}),
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -359,7 +362,6 @@ func unmarshalUserField(d identifiableGetterSetter) (UserField, error) {
 		}
 
 		tf.CustomFieldOptions = customFieldOptions
-		debugLog(tf.CustomFieldOptions, "customFieldOption")
 	}
 
 	return tf, nil
@@ -400,8 +402,6 @@ func CreateUserField(ctx context.Context, z *newClient.Client, userField UserFie
 	}
 	data.UserField = userField
 
-	debugLog(data, "createUserField")
-
 	body, err := z.Post(ctx, "/user_fields.json", data)
 	if err != nil {
 		return UserField{}, err
@@ -428,6 +428,9 @@ func readUserField(ctx context.Context, d identifiableGetterSetter, zd *newClien
 	}
 
 	field, err := GetUserField(ctx, zd, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -460,8 +463,6 @@ func updateUserField(ctx context.Context, d identifiableGetterSetter, zd *newCli
 		return diag.FromErr(err)
 	}
 
-	debugLog(tf, "unmarshalled data")
-
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
@@ -516,7 +517,6 @@ func GetUserField(ctx context.Context, z *newClient.Client, userID int64) (UserF
 	}
 
 	err = json.Unmarshal(body, &result)
-	debugLog(result, "## GET ##")
 	if err != nil {
 		return UserField{}, err
 	}
@@ -533,8 +533,6 @@ func UpdateUserField(ctx context.Context, z *newClient.Client, ticketID int64, f
 
 	data.UserField = field
 
-	debugLog(data, "updateUserField")
-
 	body, err := z.Put(ctx, fmt.Sprintf("/user_fields/%d.json", ticketID), data)
 
 	if err != nil {
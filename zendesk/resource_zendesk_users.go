@@ -202,6 +202,9 @@ func readUser(ctx context.Context, d identifiableGetterSetter, zd *newClient.Cli
 	}
 
 	user, err := zd.GetUser(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -19,9 +19,12 @@ func resourceZendeskView() *schema.Resource {
 		ReadContext:   resourceZendeskViewsRead,
 		UpdateContext: resourceZendeskViewsUpdate,
 		DeleteContext: resourceZendeskViewsDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importByAttribute("/views.json", "views",
+			func(v models.View) int64 { return v.ID },
+			map[string]func(models.View) string{
+				"title": func(v models.View) string { return v.Title },
+			}),
+		CustomizeDiff: validateConditionsDiff,
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -41,7 +44,7 @@ func resourceZendeskView() *schema.Resource {
 				Computed:    true,
 			},
 			"position": {
-				Description: "IMPORTANT! in order for this to take affect an update on the resource is necessary, since only that triggers a call to update position",
+				Description: "IMPORTANT! in order for this to take affect an update on the resource is necessary, since only that triggers a call to update position. Leave it unset when the view is listed in `zendesk_view_order`, since both would set the position and undo each other on every apply.",
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
@@ -320,6 +323,9 @@ func readViews(ctx context.Context, d identifiableGetterSetter, zd *newClient.Cl
 	}
 
 	field, err := zd.GetView(ctx, id)
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -392,14 +398,16 @@ func viewConditionSchema(desc string) *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"field": {
-					Description: "The name of a ticket field.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "The name of a ticket field. Custom fields are written as `custom_fields_<id>`.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionField(conditionView),
 				},
 				"operator": {
-					Description: "A comparison operator.",
-					Type:        schema.TypeString,
-					Required:    true,
+					Description:      "A comparison operator.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validateConditionOperator(),
 				},
 				"value": {
 					Description: "The value of a ticket field.",
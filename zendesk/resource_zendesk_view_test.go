@@ -0,0 +1,40 @@
+package zendesk
+
+import (
+	"testing"
+)
+
+func TestViewCassette(t *testing.T) {
+	meta := testCassetteProvider(t, "view")
+
+	view := map[string]interface{}{
+		"title":       "VIP tickets",
+		"description": "Open tickets of VIP customers",
+		"all": []interface{}{
+			map[string]interface{}{"field": "status", "operator": "less_than", "value": "solved"},
+		},
+		"any": []interface{}{
+			map[string]interface{}{"field": "current_tags", "operator": "includes", "value": "vip"},
+		},
+		"columns":     []interface{}{"subject", "requester", "created"},
+		"group_by":    "status",
+		"group_order": "asc",
+		"sort_by":     "created",
+		"sort_order":  "desc",
+	}
+	updated := map[string]interface{}{}
+	for k, v := range view {
+		updated[k] = v
+	}
+	updated["title"] = "VIP customer tickets"
+	updated["sort_order"] = "asc"
+
+	d := testCassetteLifecycle(t, meta, "zendesk_view", view, updated)
+
+	if v := d.Get("columns").([]interface{}); len(v) != 3 || v[0] != "subject" {
+		t.Fatalf("view had columns %v. should have been %v", v, view["columns"])
+	}
+	if v := d.Get("sort_by"); v != "created" {
+		t.Fatalf("view had sort_by %v. should have been created", v)
+	}
+}
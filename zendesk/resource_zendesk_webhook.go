@@ -262,6 +262,9 @@ func readWebhook(ctx context.Context, d identifiableGetterSetter, zd client.Webh
 	var diags diag.Diagnostics
 
 	wh, err := zd.GetWebhook(ctx, d.Id())
+	if handleNotFound(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
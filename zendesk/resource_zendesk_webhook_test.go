@@ -138,6 +138,24 @@ func TestReadWebhook(t *testing.T) {
 	}
 }
 
+func TestReadWebhookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	i := newIdentifiableGetterSetter()
+	i.SetId("12345")
+
+	m.EXPECT().GetWebhook(gomock.Any(), gomock.Eq("12345")).Return(nil, newZendeskError(http.StatusNotFound))
+	if diags := readWebhook(context.Background(), i, m); len(diags) != 0 {
+		t.Fatalf("readWebhook returned an error for a deleted webhook: %v", diags)
+	}
+
+	if v := i.Id(); v != "" {
+		t.Fatalf("readWebhook did not remove the webhook from state. Id was %s", v)
+	}
+}
+
 func TestUpdateWebhook(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
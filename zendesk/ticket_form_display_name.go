@@ -0,0 +1,367 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/nukosuke/go-zendesk/zendesk"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// ticketFormDisplayNameAPI manages the dynamic content item which holds the
+// display names of a ticket form per locale
+type ticketFormDisplayNameAPI interface {
+	client.DynamicContentAPI
+	newClient.DynamicContentVariantAPI
+	GetLocales(ctx context.Context) ([]newClient.Locale, error)
+}
+
+// displayNameVariant is an entry of display_name_variant
+type displayNameVariant struct {
+	locale  string
+	content string
+}
+
+func displayNameVariantSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:   "Display names of the form per locale. They are kept in a dynamic content item managed with the form and named after it with a random suffix, since item names must be unique. Its placeholder becomes the `display_name`. The first variant is the default one, shown for locales without a variant.",
+		Type:          schema.TypeList,
+		Optional:      true,
+		ConflictsWith: []string{"display_name"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"locale": {
+					Description: "A locale of the account, such as `en-US` or `de`, as listed by the `zendesk_locales` data source.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"content": {
+					Description: "The display name of the form in this locale.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func expandDisplayNameVariants(v interface{}) []displayNameVariant {
+	var variants []displayNameVariant
+	for _, e := range v.([]interface{}) {
+		block, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		variants = append(variants, displayNameVariant{
+			locale:  block["locale"].(string),
+			content: block["content"].(string),
+		})
+	}
+	return variants
+}
+
+// getLocaleIDs maps the locale codes of the account to their IDs
+func getLocaleIDs(ctx context.Context, zd interface {
+	GetLocales(ctx context.Context) ([]newClient.Locale, error)
+}) (map[string]int64, error) {
+	locales, err := zd.GetLocales(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list locales: %v", err)
+	}
+
+	ids := map[string]int64{}
+	for _, l := range locales {
+		ids[l.Locale] = l.ID
+	}
+	return ids, nil
+}
+
+// displayNameItemName names the dynamic content item of a form. Zendesk requires
+// item names to be unique, and forms copied between brands often share a name,
+// so the name ends with a suffix which is kept when the form is renamed.
+func displayNameItemName(formName string, previous string) string {
+	suffix := fmt.Sprintf("%08x", rand.Uint32())
+	if m := displayNameItemSuffix.FindStringSubmatch(previous); m != nil {
+		suffix = m[1]
+	}
+	return fmt.Sprintf("Ticket form %s (%s)", formName, suffix)
+}
+
+var displayNameItemSuffix = regexp.MustCompile(` \(([0-9a-f]{8})\)$`)
+
+// validateDisplayNameVariants checks that every locale is used once and, when
+// localeIDs isn't nil, is a locale of the account. Unknown locales are "".
+func validateDisplayNameVariants(variants []displayNameVariant, localeIDs map[string]int64) error {
+	seen := map[string]bool{}
+	for _, variant := range variants {
+		if variant.locale == "" {
+			continue
+		}
+		if seen[variant.locale] {
+			return fmt.Errorf("locale %s has more than one display_name_variant", variant.locale)
+		}
+		seen[variant.locale] = true
+
+		if localeIDs == nil {
+			continue
+		}
+		if _, ok := localeIDs[variant.locale]; !ok {
+			locales := make([]string, 0, len(localeIDs))
+			for l := range localeIDs {
+				locales = append(locales, l)
+			}
+			sort.Strings(locales)
+			return fmt.Errorf("locale %s of display_name_variant is not a locale of the account, which has %s", variant.locale, strings.Join(locales, ", "))
+		}
+	}
+	return nil
+}
+
+// validateDisplayNameVariantsDiff checks the locales of display_name_variant
+// against the locales of the account, as listed by zendesk_locales
+func validateDisplayNameVariantsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	v, ok := d.GetOk("display_name_variant")
+	if !ok {
+		return nil
+	}
+	variants := expandDisplayNameVariants(v)
+
+	var localeIDs map[string]int64
+	if zd, ok := meta.(interface {
+		GetLocales(ctx context.Context) ([]newClient.Locale, error)
+	}); ok && zd != nil {
+		ids, err := getLocaleIDs(ctx, zd)
+		if err != nil {
+			return err
+		}
+		localeIDs = ids
+	}
+
+	return validateDisplayNameVariants(variants, localeIDs)
+}
+
+// applyDisplayNameVariants creates or updates the dynamic content item holding
+// display_name_variant and sets display_name to its placeholder. It runs before
+// the form is created or updated, so that the form can use the placeholder.
+func applyDisplayNameVariants(ctx context.Context, d identifiableGetterSetter, zd ticketFormDisplayNameAPI) error {
+	v, ok := d.GetOk("display_name_variant")
+	if !ok {
+		return nil
+	}
+	variants := expandDisplayNameVariants(v)
+
+	localeIDs, err := getLocaleIDs(ctx, zd)
+	if err != nil {
+		return err
+	}
+	if err := validateDisplayNameVariants(variants, localeIDs); err != nil {
+		return err
+	}
+
+	var item client.DynamicContentItem
+	if id := int64(d.Get("display_name_dynamic_content_id").(int)); id != 0 {
+		item, err = zd.GetDynamicContentItem(ctx, id)
+		if err != nil {
+			return fmt.Errorf("could not fetch dynamic content item %d of display_name_variant: %v", id, err)
+		}
+
+		if name := displayNameItemName(d.Get("name").(string), item.Name); name != item.Name {
+			item.Name = name
+			_, err = zd.UpdateDynamicContentItem(ctx, item.ID, item)
+			if err != nil {
+				return fmt.Errorf("could not rename dynamic content item %d of display_name_variant: %v", id, err)
+			}
+		}
+
+		err = syncDisplayNameVariants(ctx, zd, item, variants, localeIDs)
+		if err != nil {
+			return err
+		}
+	} else {
+		item = client.DynamicContentItem{
+			Name:            displayNameItemName(d.Get("name").(string), ""),
+			DefaultLocaleID: localeIDs[variants[0].locale],
+		}
+		for i, variant := range variants {
+			item.Variants = append(item.Variants, client.DynamicContentVariant{
+				Content:  variant.content,
+				LocaleID: localeIDs[variant.locale],
+				Active:   true,
+				Default:  i == 0,
+			})
+		}
+
+		item, err = zd.CreateDynamicContentItem(ctx, item)
+		if err != nil {
+			return fmt.Errorf("could not create dynamic content item for display_name_variant: %v", err)
+		}
+	}
+
+	err = d.Set("display_name_dynamic_content_id", int(item.ID))
+	if err != nil {
+		return err
+	}
+	return d.Set("display_name", item.Placeholder)
+}
+
+// syncDisplayNameVariants changes the variants of item to variants. The new
+// default variant is set first, so that the previous one can be deleted.
+func syncDisplayNameVariants(ctx context.Context, zd newClient.DynamicContentVariantAPI, item client.DynamicContentItem, variants []displayNameVariant, localeIDs map[string]int64) error {
+	existing := map[int64]client.DynamicContentVariant{}
+	for _, variant := range item.Variants {
+		existing[variant.LocaleID] = variant
+	}
+
+	wanted := map[int64]bool{}
+	for i, variant := range variants {
+		localeID := localeIDs[variant.locale]
+		wanted[localeID] = true
+
+		current, ok := existing[localeID]
+		if !ok {
+			_, err := zd.CreateDynamicContentVariant(ctx, item.ID, client.DynamicContentVariant{
+				Content:  variant.content,
+				LocaleID: localeID,
+				Active:   true,
+				Default:  i == 0,
+			})
+			if err != nil {
+				return fmt.Errorf("could not create display_name_variant %s: %v", variant.locale, err)
+			}
+			continue
+		}
+
+		if current.Content == variant.content && current.Default == (i == 0) {
+			continue
+		}
+		current.Content = variant.content
+		current.Default = i == 0
+		_, err := zd.UpdateDynamicContentVariant(ctx, item.ID, current)
+		if err != nil {
+			return fmt.Errorf("could not update display_name_variant %s: %v", variant.locale, err)
+		}
+	}
+
+	for localeID, variant := range existing {
+		if wanted[localeID] {
+			continue
+		}
+		err := zd.DeleteDynamicContentVariant(ctx, item.ID, variant.ID)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("could not delete display_name_variant with locale id %d: %v", localeID, err)
+		}
+	}
+
+	return nil
+}
+
+// detachDisplayNameVariants prepares the removal of display_name_variant: the
+// form goes back to its name as display name, unless display_name is set.
+// It returns the ID of the dynamic content item to delete once the form no
+// longer uses its placeholder, or 0.
+func detachDisplayNameVariants(d identifiableGetterSetter) (int64, error) {
+	id := int64(d.Get("display_name_dynamic_content_id").(int))
+	if _, ok := d.GetOk("display_name_variant"); ok || id == 0 {
+		return 0, nil
+	}
+
+	if strings.HasPrefix(d.Get("display_name").(string), "{{dc.") {
+		err := d.Set("display_name", d.Get("name"))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, d.Set("display_name_dynamic_content_id", 0)
+}
+
+// deleteDisplayNameItem deletes the dynamic content item which held display_name_variant
+func deleteDisplayNameItem(ctx context.Context, zd client.DynamicContentAPI, id int64) error {
+	if id == 0 {
+		return nil
+	}
+
+	err := zd.DeleteDynamicContentItem(ctx, id)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("could not delete dynamic content item %d of display_name_variant: %v", id, err)
+	}
+	return nil
+}
+
+// readDisplayNameVariants sets display_name_variant from the dynamic content
+// item of the form, in the order of the prior state
+func readDisplayNameVariants(ctx context.Context, d identifiableGetterSetter, zd ticketFormDisplayNameAPI) error {
+	id := int64(d.Get("display_name_dynamic_content_id").(int))
+	if id == 0 {
+		return nil
+	}
+
+	item, err := zd.GetDynamicContentItem(ctx, id)
+	if isNotFound(err) {
+		err = d.Set("display_name_dynamic_content_id", 0)
+		if err != nil {
+			return err
+		}
+		return d.Set("display_name_variant", nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	localeIDs, err := getLocaleIDs(ctx, zd)
+	if err != nil {
+		return err
+	}
+	locales := map[int64]string{}
+	for locale, localeID := range localeIDs {
+		locales[localeID] = locale
+	}
+
+	contents := map[string]string{}
+	var order []string
+	for _, variant := range item.Variants {
+		locale, ok := locales[variant.LocaleID]
+		if !ok {
+			locale = fmt.Sprintf("%d", variant.LocaleID)
+		}
+		contents[locale] = variant.Content
+		if variant.Default {
+			order = append([]string{locale}, order...)
+		} else {
+			order = append(order, locale)
+		}
+	}
+
+	// keep the configured order, as long as the default variant stays first
+	if v, ok := d.GetOk("display_name_variant"); ok {
+		prior := expandDisplayNameVariants(v)
+		if len(prior) > 0 && len(order) > 0 && prior[0].locale == order[0] {
+			var sorted []string
+			for _, variant := range prior {
+				if _, ok := contents[variant.locale]; ok {
+					sorted = append(sorted, variant.locale)
+				}
+			}
+			for _, locale := range order {
+				if !containsString(sorted, locale) {
+					sorted = append(sorted, locale)
+				}
+			}
+			order = sorted
+		}
+	}
+
+	var blocks []map[string]interface{}
+	for _, locale := range order {
+		blocks = append(blocks, map[string]interface{}{
+			"locale":  locale,
+			"content": contents[locale],
+		})
+	}
+	return d.Set("display_name_variant", blocks)
+}
@@ -0,0 +1,323 @@
+package zendesk
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nukosuke/go-zendesk/zendesk"
+	newClient "github.com/nukosuke/terraform-provider-zendesk/zendesk/client"
+)
+
+// fakeDisplayNameAPI keeps dynamic content items in memory
+type fakeDisplayNameAPI struct {
+	items  map[int64]*zendesk.DynamicContentItem
+	nextID int64
+}
+
+func newFakeDisplayNameAPI() *fakeDisplayNameAPI {
+	return &fakeDisplayNameAPI{items: map[int64]*zendesk.DynamicContentItem{}, nextID: 100}
+}
+
+func (f *fakeDisplayNameAPI) GetLocales(ctx context.Context) ([]newClient.Locale, error) {
+	return []newClient.Locale{
+		{ID: 1, Locale: "en-US"},
+		{ID: 8, Locale: "de"},
+		{ID: 16, Locale: "fr"},
+	}, nil
+}
+
+func (f *fakeDisplayNameAPI) GetDynamicContentItems(ctx context.Context) ([]zendesk.DynamicContentItem, zendesk.Page, error) {
+	return nil, zendesk.Page{}, nil
+}
+
+func (f *fakeDisplayNameAPI) CreateDynamicContentItem(ctx context.Context, item zendesk.DynamicContentItem) (zendesk.DynamicContentItem, error) {
+	f.nextID++
+	item.ID = f.nextID
+	item.Placeholder = "{{dc.ticket_form_snowboard_problem}}"
+	for i := range item.Variants {
+		f.nextID++
+		item.Variants[i].ID = f.nextID
+	}
+	f.items[item.ID] = &item
+	return item, nil
+}
+
+func (f *fakeDisplayNameAPI) GetDynamicContentItem(ctx context.Context, id int64) (zendesk.DynamicContentItem, error) {
+	item, ok := f.items[id]
+	if !ok {
+		return zendesk.DynamicContentItem{}, zendesk.NewError(nil, &http.Response{StatusCode: http.StatusNotFound})
+	}
+	return *item, nil
+}
+
+func (f *fakeDisplayNameAPI) UpdateDynamicContentItem(ctx context.Context, id int64, item zendesk.DynamicContentItem) (zendesk.DynamicContentItem, error) {
+	f.items[id].Name = item.Name
+	return *f.items[id], nil
+}
+
+func (f *fakeDisplayNameAPI) DeleteDynamicContentItem(ctx context.Context, id int64) error {
+	delete(f.items, id)
+	return nil
+}
+
+func (f *fakeDisplayNameAPI) CreateDynamicContentVariant(ctx context.Context, itemID int64, variant zendesk.DynamicContentVariant) (zendesk.DynamicContentVariant, error) {
+	item := f.items[itemID]
+	if variant.Default {
+		for i := range item.Variants {
+			item.Variants[i].Default = false
+		}
+	}
+	f.nextID++
+	variant.ID = f.nextID
+	item.Variants = append(item.Variants, variant)
+	return variant, nil
+}
+
+func (f *fakeDisplayNameAPI) UpdateDynamicContentVariant(ctx context.Context, itemID int64, variant zendesk.DynamicContentVariant) (zendesk.DynamicContentVariant, error) {
+	item := f.items[itemID]
+	for i := range item.Variants {
+		if variant.Default {
+			item.Variants[i].Default = false
+		}
+		if item.Variants[i].ID == variant.ID {
+			item.Variants[i].Content = variant.Content
+			item.Variants[i].Default = item.Variants[i].Default || variant.Default
+		}
+	}
+	return variant, nil
+}
+
+func (f *fakeDisplayNameAPI) DeleteDynamicContentVariant(ctx context.Context, itemID int64, id int64) error {
+	item := f.items[itemID]
+	for i, variant := range item.Variants {
+		if variant.ID == id {
+			item.Variants = append(item.Variants[:i], item.Variants[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// variantContents returns the contents of an item by locale ID, with the default variant under 0
+func variantContents(item *zendesk.DynamicContentItem) map[int64]string {
+	contents := map[int64]string{}
+	for _, variant := range item.Variants {
+		contents[variant.LocaleID] = variant.Content
+		if variant.Default {
+			contents[0] = variant.Content
+		}
+	}
+	return contents
+}
+
+func TestValidateDisplayNameVariants(t *testing.T) {
+	localeIDs := map[string]int64{"en-US": 1, "de": 8}
+
+	cases := []struct {
+		name      string
+		variants  []displayNameVariant
+		localeIDs map[string]int64
+		err       string
+	}{
+		{"valid", []displayNameVariant{{"en-US", "Problem"}, {"de", "Problem"}}, localeIDs, ""},
+		{"duplicate locale", []displayNameVariant{{"de", "Problem"}, {"de", "Schaden"}}, nil, "locale de has more than one display_name_variant"},
+		{"unknown locale", []displayNameVariant{{"en-US", "Problem"}, {"xx", "Problem"}}, localeIDs, "locale xx of display_name_variant is not a locale of the account, which has de, en-US"},
+		{"locales not listed", []displayNameVariant{{"xx", "Problem"}}, nil, ""},
+		{"locale not known yet", []displayNameVariant{{"", "Problem"}}, localeIDs, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateDisplayNameVariants(c.variants, c.localeIDs)
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("validateDisplayNameVariants returned an error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.err {
+				t.Fatalf("validateDisplayNameVariants returned %v. should have been %s", err, c.err)
+			}
+		})
+	}
+}
+
+func TestApplyDisplayNameVariants(t *testing.T) {
+	zd := newFakeDisplayNameAPI()
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, map[string]interface{}{
+		"name": "Snowboard Problem",
+		"display_name_variant": []interface{}{
+			map[string]interface{}{"locale": "en-US", "content": "Snowboard problem"},
+			map[string]interface{}{"locale": "de", "content": "Snowboard-Problem"},
+		},
+	})
+
+	if err := applyDisplayNameVariants(context.Background(), d, zd); err != nil {
+		t.Fatalf("applyDisplayNameVariants returned an error: %v", err)
+	}
+
+	id := int64(d.Get("display_name_dynamic_content_id").(int))
+	item, ok := zd.items[id]
+	if !ok {
+		t.Fatalf("display_name_dynamic_content_id was %d. should have been the created item", id)
+	}
+	if v := d.Get("display_name"); v != item.Placeholder {
+		t.Fatalf("display_name was %v. should have been the placeholder %s", v, item.Placeholder)
+	}
+	if item.DefaultLocaleID != 1 {
+		t.Fatalf("item had default locale %d. should have been 1", item.DefaultLocaleID)
+	}
+
+	expected := map[int64]string{0: "Snowboard problem", 1: "Snowboard problem", 8: "Snowboard-Problem"}
+	if v := variantContents(item); !reflect.DeepEqual(v, expected) {
+		t.Fatalf("item had variants %v. should have been %v", v, expected)
+	}
+
+	tf, err := unmarshalTicketForm(d)
+	if err != nil {
+		t.Fatalf("unmarshalTicketForm returned an error: %v", err)
+	}
+	if tf.DisplayName != item.Placeholder {
+		t.Fatalf("ticket form had display name %s. should have been the placeholder %s", tf.DisplayName, item.Placeholder)
+	}
+}
+
+func TestDisplayNameItemName(t *testing.T) {
+	zd := newFakeDisplayNameAPI()
+	config := map[string]interface{}{
+		"name": "Snowboard Problem",
+		"display_name_variant": []interface{}{
+			map[string]interface{}{"locale": "en-US", "content": "Snowboard problem"},
+		},
+	}
+
+	// the same form copied to two brands
+	var ids []int64
+	for i := 0; i < 2; i++ {
+		d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, config)
+		if err := applyDisplayNameVariants(context.Background(), d, zd); err != nil {
+			t.Fatalf("applyDisplayNameVariants returned an error: %v", err)
+		}
+		ids = append(ids, int64(d.Get("display_name_dynamic_content_id").(int)))
+	}
+
+	first, second := zd.items[ids[0]].Name, zd.items[ids[1]].Name
+	if !regexp.MustCompile(`^Ticket form Snowboard Problem \([0-9a-f]{8}\)$`).MatchString(first) {
+		t.Fatalf("dynamic content item was named %q. should have been the form name with a suffix", first)
+	}
+	if first == second {
+		t.Fatalf("dynamic content items of two forms named alike were both named %q. should have been unique", first)
+	}
+
+	config["name"] = "Snowboard Damage"
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, config)
+	d.SetId("47")
+	if err := d.Set("display_name_dynamic_content_id", int(ids[0])); err != nil {
+		t.Fatalf("could not set display_name_dynamic_content_id: %v", err)
+	}
+	if err := applyDisplayNameVariants(context.Background(), d, zd); err != nil {
+		t.Fatalf("applyDisplayNameVariants returned an error: %v", err)
+	}
+
+	expected := "Ticket form Snowboard Damage" + first[len("Ticket form Snowboard Problem"):]
+	if v := zd.items[ids[0]].Name; v != expected {
+		t.Fatalf("dynamic content item was named %q after renaming the form. should have been %q", v, expected)
+	}
+}
+
+func TestSyncDisplayNameVariants(t *testing.T) {
+	zd := newFakeDisplayNameAPI()
+	item, _ := zd.CreateDynamicContentItem(context.Background(), zendesk.DynamicContentItem{
+		DefaultLocaleID: 1,
+		Variants: []zendesk.DynamicContentVariant{
+			{LocaleID: 1, Content: "Snowboard problem", Default: true},
+			{LocaleID: 8, Content: "Snowboard-Problem"},
+		},
+	})
+
+	localeIDs, _ := getLocaleIDs(context.Background(), zd)
+	variants := []displayNameVariant{
+		{"de", "Snowboardschaden"},
+		{"fr", "Problème de snowboard"},
+	}
+	if err := syncDisplayNameVariants(context.Background(), zd, item, variants, localeIDs); err != nil {
+		t.Fatalf("syncDisplayNameVariants returned an error: %v", err)
+	}
+
+	expected := map[int64]string{0: "Snowboardschaden", 8: "Snowboardschaden", 16: "Problème de snowboard"}
+	if v := variantContents(zd.items[item.ID]); !reflect.DeepEqual(v, expected) {
+		t.Fatalf("item had variants %v. should have been %v", v, expected)
+	}
+}
+
+func TestReadDisplayNameVariants(t *testing.T) {
+	zd := newFakeDisplayNameAPI()
+	item, _ := zd.CreateDynamicContentItem(context.Background(), zendesk.DynamicContentItem{
+		DefaultLocaleID: 1,
+		Variants: []zendesk.DynamicContentVariant{
+			{LocaleID: 16, Content: "Problème de snowboard"},
+			{LocaleID: 1, Content: "Snowboard problem", Default: true},
+			{LocaleID: 8, Content: "Snowboard-Problem"},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, map[string]interface{}{
+		"name": "Snowboard Problem",
+		"display_name_variant": []interface{}{
+			map[string]interface{}{"locale": "en-US", "content": "Snowboard problem"},
+			map[string]interface{}{"locale": "de", "content": "Snowboard-Problem"},
+		},
+	})
+	d.SetId("47")
+	if err := d.Set("display_name_dynamic_content_id", int(item.ID)); err != nil {
+		t.Fatalf("could not set display_name_dynamic_content_id: %v", err)
+	}
+
+	if err := readDisplayNameVariants(context.Background(), d, zd); err != nil {
+		t.Fatalf("readDisplayNameVariants returned an error: %v", err)
+	}
+
+	var locales []string
+	for _, variant := range expandDisplayNameVariants(d.Get("display_name_variant")) {
+		locales = append(locales, variant.locale)
+	}
+	if expected := []string{"en-US", "de", "fr"}; !reflect.DeepEqual(locales, expected) {
+		t.Fatalf("display_name_variant had locales %v. should have been %v", locales, expected)
+	}
+
+	delete(zd.items, item.ID)
+	if err := readDisplayNameVariants(context.Background(), d, zd); err != nil {
+		t.Fatalf("readDisplayNameVariants returned an error for a deleted item: %v", err)
+	}
+	if v := d.Get("display_name_dynamic_content_id"); v != 0 {
+		t.Fatalf("display_name_dynamic_content_id was %v. should have been cleared", v)
+	}
+}
+
+func TestDetachDisplayNameVariants(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceZendeskTicketForm().Schema, map[string]interface{}{
+		"name": "Snowboard Problem",
+	})
+	d.SetId("47")
+	if err := d.Set("display_name_dynamic_content_id", 101); err != nil {
+		t.Fatalf("could not set display_name_dynamic_content_id: %v", err)
+	}
+	if err := d.Set("display_name", "{{dc.ticket_form_snowboard_problem}}"); err != nil {
+		t.Fatalf("could not set display_name: %v", err)
+	}
+
+	removed, err := detachDisplayNameVariants(d)
+	if err != nil {
+		t.Fatalf("detachDisplayNameVariants returned an error: %v", err)
+	}
+	if removed != 101 {
+		t.Fatalf("detachDisplayNameVariants returned %d. should have returned the item 101", removed)
+	}
+	if v := d.Get("display_name"); v != "Snowboard Problem" {
+		t.Fatalf("display_name was %v. should have been the name of the form", v)
+	}
+}
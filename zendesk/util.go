@@ -1,14 +1,18 @@
 package zendesk
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/nukosuke/go-zendesk/zendesk"
 )
 
 type getter interface {
@@ -137,13 +141,48 @@ func atoi64(anum string) (int64, error) {
 	return strconv.ParseInt(anum, 10, 64)
 }
 
-func debugLog(jsonableData interface{}, desc string) error {
-	marshaled, err := json.MarshalIndent(jsonableData, "", "   ")
-	if err != nil {
-		return err
+// isNotFound reports whether err is a Zendesk API error with status 404
+func isNotFound(err error) bool {
+	var zdErr client.Error
+	if !errors.As(err, &zdErr) {
+		return false
 	}
-	fmt.Printf("### LOG %s START ###\n", desc)
-	fmt.Println(string(marshaled))
-	fmt.Printf("### LOG %s END ###\n", desc)
-	return nil
+
+	return zdErr.Status() == http.StatusNotFound
+}
+
+// handleNotFound clears the resource ID when err says the object no longer
+// exists in Zendesk, so that Terraform plans to create it again instead of
+// failing the refresh. It returns true if the ID was cleared.
+func handleNotFound(ctx context.Context, d identifiable, err error) bool {
+	if !isNotFound(err) {
+		return false
+	}
+
+	tflog.Warn(ctx, "Zendesk object not found, removing from state", map[string]interface{}{
+		"id": d.Id(),
+	})
+	d.SetId("")
+	return true
+}
+
+// getBoolOk is like GetOk for boolean attributes, but also reports false
+// values which are explicitly set in the configuration.
+func getBoolOk(d getter, key string) (bool, bool) {
+	if rd, ok := d.(interface{ GetRawConfig() cty.Value }); ok {
+		config := rd.GetRawConfig()
+		if config.IsKnown() && !config.IsNull() && config.Type().IsObjectType() && config.Type().HasAttribute(key) {
+			v := config.GetAttr(key)
+			if v.IsNull() || !v.IsKnown() {
+				return false, false
+			}
+			return v.True(), true
+		}
+	}
+
+	v, ok := d.GetOk(key)
+	if !ok {
+		return false, false
+	}
+	return v.(bool), true
 }
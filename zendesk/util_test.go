@@ -1,13 +1,17 @@
 package zendesk
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/nukosuke/go-zendesk/zendesk"
 )
 
 func TestIsValidFile(t *testing.T) {
@@ -30,6 +34,51 @@ func TestIsValidFile(t *testing.T) {
 	}
 }
 
+func TestIsNotFound(t *testing.T) {
+	if !isNotFound(newZendeskError(http.StatusNotFound)) {
+		t.Fatalf("isNotFound did not detect a 404 error")
+	}
+
+	if !isNotFound(fmt.Errorf("wrapped: %w", newZendeskError(http.StatusNotFound))) {
+		t.Fatalf("isNotFound did not detect a wrapped 404 error")
+	}
+
+	if isNotFound(newZendeskError(http.StatusUnauthorized)) {
+		t.Fatalf("isNotFound returned true for a 401 error")
+	}
+
+	if isNotFound(errors.New("connection refused")) {
+		t.Fatalf("isNotFound returned true for a non zendesk error")
+	}
+
+	if isNotFound(nil) {
+		t.Fatalf("isNotFound returned true for a nil error")
+	}
+}
+
+func TestHandleNotFound(t *testing.T) {
+	d := newIdentifiableGetterSetter()
+	d.SetId("1234")
+
+	if handleNotFound(context.Background(), d, newZendeskError(http.StatusInternalServerError)) {
+		t.Fatalf("handleNotFound returned true for a 500 error")
+	}
+	if v := d.Id(); v != "1234" {
+		t.Fatalf("handleNotFound cleared the id on a 500 error. id was %s", v)
+	}
+
+	if !handleNotFound(context.Background(), d, newZendeskError(http.StatusNotFound)) {
+		t.Fatalf("handleNotFound returned false for a 404 error")
+	}
+	if v := d.Id(); v != "" {
+		t.Fatalf("handleNotFound did not clear the id. id was %s", v)
+	}
+}
+
+func newZendeskError(status int) error {
+	return zendesk.NewError(nil, &http.Response{StatusCode: status})
+}
+
 func readExampleConfig(t *testing.T, filename string) string {
 	dir, err := filepath.Abs("../examples")
 	if err != nil {
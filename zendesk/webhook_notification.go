@@ -0,0 +1,147 @@
+package zendesk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	webhookNotificationKey   = "webhook_notification"
+	webhookNotificationField = "notification_webhook"
+)
+
+// webhookNotificationSchema returns the webhook_notification block of triggers and automations.
+// Each block is sent to Zendesk as a notification_webhook action.
+func webhookNotificationSchema(desc string) *schema.Schema {
+	return &schema.Schema{
+		Description: desc,
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"webhook_id": {
+					Description: "The ID of the webhook to call, usually `zendesk_webhook.<name>.id`.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"payload": {
+					Description:      "The JSON body of the request, usually written as `jsonencode({...})`. It is stored in a canonical form, so only semantic changes show up in plans.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.AllDiag(validation.ToDiagFunc(validation.StringIsJSON), validateLiquid()),
+					StateFunc: func(v interface{}) string {
+						payload, err := canonicalJSON(v.(string))
+						if err != nil {
+							// invalid payloads are rejected by ValidateDiagFunc
+							return v.(string)
+						}
+						return payload
+					},
+				},
+			},
+		},
+	}
+}
+
+// canonicalJSON re-encodes a JSON document with sorted keys and no insignificant whitespace.
+// HTML characters are not escaped, so that Liquid tags in the payload reach Zendesk as written.
+func canonicalJSON(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return "", err
+	}
+	if decoder.More() {
+		return "", fmt.Errorf("unexpected data after the JSON document")
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// expandWebhookNotifications converts webhook_notification blocks into notification_webhook actions
+func expandWebhookNotifications(v interface{}) ([]ruleAction, error) {
+	blocks, _ := v.([]interface{})
+
+	var actions []ruleAction
+	for _, b := range blocks {
+		block, ok := b.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("could not parse webhook notification %v", b)
+		}
+
+		payload, err := canonicalJSON(block["payload"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload for webhook %s: %v", block["webhook_id"], err)
+		}
+
+		actions = append(actions, ruleAction{
+			Field: webhookNotificationField,
+			Value: []interface{}{block["webhook_id"].(string), payload},
+		})
+	}
+
+	return actions, nil
+}
+
+// flattenWebhookNotifications moves notification_webhook actions read from Zendesk into
+// webhook_notification blocks and returns the remaining actions. Webhooks which prior
+// state calls from an action block stay action blocks, as do bodies which aren't JSON.
+func flattenWebhookNotifications(actions []ruleAction, priorActions interface{}) ([]ruleAction, []map[string]interface{}) {
+	actionWebhooks := map[string]bool{}
+	for _, a := range actionBlocks(priorActions) {
+		action, ok := a.(map[string]interface{})
+		if !ok || action["field"] != webhookNotificationField {
+			continue
+		}
+
+		value, err := expandActionValue(action)
+		if err != nil {
+			continue
+		}
+		if list, ok := value.([]interface{}); ok && len(list) > 0 {
+			actionWebhooks[actionValueString(list[0])] = true
+		}
+	}
+
+	var remaining []ruleAction
+	webhooks := []map[string]interface{}{}
+	for _, action := range actions {
+		list, ok := action.Value.([]interface{})
+		if action.Field != webhookNotificationField || !ok || len(list) != 2 {
+			remaining = append(remaining, action)
+			continue
+		}
+
+		webhookID := actionValueString(list[0])
+		payload, err := canonicalJSON(actionValueString(list[1]))
+		if actionWebhooks[webhookID] || err != nil {
+			remaining = append(remaining, action)
+			continue
+		}
+
+		webhooks = append(webhooks, map[string]interface{}{
+			"webhook_id": webhookID,
+			"payload":    payload,
+		})
+	}
+
+	return remaining, webhooks
+}
@@ -0,0 +1,92 @@
+package zendesk
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	cases := map[string]string{
+		"": "",
+		`{"b": 1, "a": {"d": [1, 2], "c": 1.50}}`: `{"a":{"c":1.50,"d":[1,2]},"b":1}`,
+		"{\n  \"ticket\": \"{{ticket.id}}\"\n}":   `{"ticket":"{{ticket.id}}"}`,
+		`{"body":"{% if a > b %}&{% endif %}"}`:   `{"body":"{% if a > b %}&{% endif %}"}`,
+	}
+
+	for in, expected := range cases {
+		v, err := canonicalJSON(in)
+		if err != nil {
+			t.Fatalf("canonicalJSON(%q) returned an error: %v", in, err)
+		}
+		if v != expected {
+			t.Fatalf("canonicalJSON(%q) was %q. should have been %q", in, v, expected)
+		}
+	}
+
+	if _, err := canonicalJSON(`{"a":1} {"b":2}`); err == nil {
+		t.Fatal("canonicalJSON should have rejected two JSON documents")
+	}
+}
+
+func TestFlattenWebhookNotifications(t *testing.T) {
+	actions := []ruleAction{
+		{Field: "status", Value: "solved"},
+		{Field: "notification_webhook", Value: []interface{}{"01GB4TVBGQ", "{\"id\": \"{{ticket.id}}\"}"}},
+		{Field: "notification_webhook", Value: []interface{}{"01GB4TW3CM", "id={{ticket.id}}"}},
+	}
+
+	remaining, webhooks := flattenWebhookNotifications(actions, nil)
+	if len(remaining) != 2 || remaining[1].Field != "notification_webhook" {
+		t.Fatalf("flattenWebhookNotifications kept actions %v. should have kept status and the non-JSON webhook", remaining)
+	}
+	expected := []map[string]interface{}{{"webhook_id": "01GB4TVBGQ", "payload": `{"id":"{{ticket.id}}"}`}}
+	if !reflect.DeepEqual(webhooks, expected) {
+		t.Fatalf("flattenWebhookNotifications returned webhooks %v. should have been %v", webhooks, expected)
+	}
+
+	// webhooks called from an action block in prior state stay action blocks
+	prior := []interface{}{
+		map[string]interface{}{"field": "notification_webhook", "value": `["01GB4TVBGQ","{\"id\": \"{{ticket.id}}\"}"]`},
+	}
+	remaining, webhooks = flattenWebhookNotifications(actions, prior)
+	if len(remaining) != 3 || len(webhooks) != 0 {
+		t.Fatalf("flattenWebhookNotifications returned actions %v and webhooks %v. should have kept every action", remaining, webhooks)
+	}
+}
+
+func TestTriggerWebhookNotificationRoundTrip(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceZendeskTrigger().Schema, map[string]interface{}{
+		"title": "Notify Slack",
+		"webhook_notification": []interface{}{
+			map[string]interface{}{
+				"webhook_id": "01GB4TVBGQ",
+				"payload":    `{"text": "Ticket {{ticket.id}} was updated", "channel": "#support"}`,
+			},
+		},
+	})
+
+	trg, err := unmarshalTrigger(d)
+	if err != nil {
+		t.Fatalf("unmarshalTrigger returned an error: %v", err)
+	}
+	expected := []zendesk.TriggerAction{{
+		Field: "notification_webhook",
+		Value: []interface{}{"01GB4TVBGQ", `{"channel":"#support","text":"Ticket {{ticket.id}} was updated"}`},
+	}}
+	if !reflect.DeepEqual(trg.Actions, expected) {
+		t.Fatalf("trigger had actions %v. should have been %v", trg.Actions, expected)
+	}
+
+	if err := marshalTrigger(trg, d); err != nil {
+		t.Fatalf("marshalTrigger returned an error: %v", err)
+	}
+	if v := d.Get("webhook_notification.0.payload"); v != `{"channel":"#support","text":"Ticket {{ticket.id}} was updated"}` {
+		t.Fatalf("webhook_notification had payload %v. should have been the canonical payload", v)
+	}
+	if v := d.Get("action").(*schema.Set).Len(); v != 0 {
+		t.Fatalf("trigger had %d action blocks. should have had none", v)
+	}
+}